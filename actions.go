@@ -0,0 +1,411 @@
+package wo
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/pkg/errors"
+)
+
+// buttonsByName maps the names of Buttons, as given by Button.String,
+// to their Button. KeyUnknown is left out, as a window cannot tell
+// whether it is pressed.
+var buttonsByName = func() map[string]pixelgl.Button {
+	buttons := make(map[string]pixelgl.Button)
+	for b := pixelgl.Button(0); b <= pixelgl.KeyLast; b++ {
+		if name := b.String(); name != "Invalid" {
+			buttons[name] = b
+		}
+	}
+	return buttons
+}()
+
+// ParseButton returns the Button with a name given by Button.String,
+// such as "Space", "A" or "MouseButtonLeft".
+func ParseButton(name string) (pixelgl.Button, error) {
+	button, ok := buttonsByName[name]
+	if !ok {
+		return pixelgl.KeyUnknown, errors.Errorf("unknown button %q", name)
+	}
+	return button, nil
+}
+
+// JustPressedButton returns a Button that has just been pressed down,
+// such as to rebind an action to the next button a player presses.
+func JustPressedButton(input Input) (pixelgl.Button, bool) {
+	for b := pixelgl.Button(0); b <= pixelgl.KeyLast; b++ {
+		if input.JustPressed(b) {
+			return b, true
+		}
+	}
+	return pixelgl.KeyUnknown, false
+}
+
+// JustPressedGamepadButton returns a button of a joystick that has
+// just been pressed down, such as to rebind an action to the next
+// button a player presses on their gamepad.
+func JustPressedGamepadButton(input Input) (GamepadButton, bool) {
+	gamepads := InputGamepads(input)
+	for js := Joystick1; js <= JoystickLast; js++ {
+		for b := 0; b < gamepads.JoystickButtonCount(js); b++ {
+			if gamepads.JoystickJustPressed(js, b) {
+				return GamepadButton{Joystick: js, Button: b}, true
+			}
+		}
+	}
+	return GamepadButton{}, false
+}
+
+// AxisBinding binds an axis to the Buttons that move it toward -1
+// and the Buttons that move it toward 1, and to analog axes of
+// joysticks that move it anywhere in between.
+type AxisBinding struct {
+	Negative []pixelgl.Button
+	Positive []pixelgl.Button
+	Gamepad  []GamepadAxis
+	// NegativeWins ignores the positive Buttons and gamepad axes
+	// while any negative Button or gamepad axis is held, instead
+	// of cancelling them out, such as to run left when left and
+	// right are both held.
+	NegativeWins bool
+}
+
+// Bindings binds named actions, such as "jump", and named axes, such as
+// "horizontal", to Buttons and the buttons and axes of gamepads. Scenes
+// query actions and axes by name instead of by Button, so that players
+// can remap their controls and play with a keyboard or a gamepad alike.
+type Bindings struct {
+	actions        map[string][]pixelgl.Button
+	gamepadActions map[string][]GamepadButton
+	axes           map[string]AxisBinding
+}
+
+// NewBindings creates Bindings without any actions or axes.
+func NewBindings() *Bindings {
+	return &Bindings{
+		actions:        make(map[string][]pixelgl.Button),
+		gamepadActions: make(map[string][]GamepadButton),
+		axes:           make(map[string]AxisBinding),
+	}
+}
+
+// BindAction binds an action to Buttons, replacing its previous Buttons.
+func (b *Bindings) BindAction(action string, buttons ...pixelgl.Button) {
+	b.actions[action] = buttons
+}
+
+// BindGamepadAction binds an action to buttons of joysticks, replacing
+// its previous gamepad buttons. Its Buttons are kept.
+func (b *Bindings) BindGamepadAction(action string, buttons ...GamepadButton) {
+	b.gamepadActions[action] = buttons
+}
+
+// BindAxis binds an axis to Buttons, replacing its previous Buttons.
+func (b *Bindings) BindAxis(axis string, binding AxisBinding) {
+	b.axes[axis] = binding
+}
+
+// Action returns the Buttons an action is bound to.
+func (b *Bindings) Action(action string) []pixelgl.Button {
+	return b.actions[action]
+}
+
+// GamepadAction returns the buttons of joysticks an action is bound to.
+func (b *Bindings) GamepadAction(action string) []GamepadButton {
+	return b.gamepadActions[action]
+}
+
+// Axis returns the Buttons an axis is bound to.
+func (b *Bindings) Axis(axis string) AxisBinding {
+	return b.axes[axis]
+}
+
+// Actions returns the names of all actions in alphabetical order.
+func (b *Bindings) Actions() []string {
+	names := make([]string, 0, len(b.actions))
+	for name := range b.actions {
+		names = append(names, name)
+	}
+	for name := range b.gamepadActions {
+		if _, ok := b.actions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Axes returns the names of all axes in alphabetical order.
+func (b *Bindings) Axes() []string {
+	names := make([]string, 0, len(b.axes))
+	for name := range b.axes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Pressed returns whether any Button or gamepad button of an action
+// is pressed down. Actions that are not bound are never pressed.
+func (b *Bindings) Pressed(input Input, action string) bool {
+	return input.Pressed(b.actions[action]...) ||
+		anyGamepadButton(input, b.gamepadActions[action], GamepadInput.JoystickPressed)
+}
+
+// JustPressed returns whether any Button or gamepad button
+// of an action has just been pressed down.
+func (b *Bindings) JustPressed(input Input, action string) bool {
+	return input.JustPressed(b.actions[action]...) ||
+		anyGamepadButton(input, b.gamepadActions[action], GamepadInput.JoystickJustPressed)
+}
+
+// JustReleased returns whether any Button or gamepad button
+// of an action has just been released.
+func (b *Bindings) JustReleased(input Input, action string) bool {
+	return input.JustReleased(b.actions[action]...) ||
+		anyGamepadButton(input, b.gamepadActions[action], GamepadInput.JoystickJustReleased)
+}
+
+// Value returns the position of an axis, from -1 to 1. Its negative
+// Buttons move it by -1 and its positive Buttons by 1, so pressing
+// both cancels out unless the negative side wins, and the positions
+// of its gamepad axes are added.
+func (b *Bindings) Value(input Input, axis string) float64 {
+	binding := b.axes[axis]
+	negative, positive := 0.0, 0.0
+	if input.Pressed(binding.Negative...) {
+		negative--
+	}
+	if input.Pressed(binding.Positive...) {
+		positive++
+	}
+	gamepads := InputGamepads(input)
+	for _, gamepadAxis := range binding.Gamepad {
+		forEachJoystick(gamepadAxis.Joystick, func(js Joystick) bool {
+			position := gamepads.JoystickAxis(js, gamepadAxis.Axis)
+			if gamepadAxis.Invert {
+				position = -position
+			}
+			if position < 0 {
+				negative += position
+			} else {
+				positive += position
+			}
+			return false
+		})
+	}
+	if binding.NegativeWins && negative < 0 {
+		positive = 0
+	}
+	return math.Max(-1, math.Min(negative+positive, 1))
+}
+
+// anyGamepadButton returns whether the state of any gamepad button is true.
+func anyGamepadButton(input Input, buttons []GamepadButton, state func(GamepadInput, Joystick, int) bool) bool {
+	if len(buttons) == 0 {
+		return false
+	}
+	gamepads := InputGamepads(input)
+	for _, button := range buttons {
+		found := forEachJoystick(button.Joystick, func(js Joystick) bool {
+			return state(gamepads, js, button.Button)
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachJoystick calls f with a Joystick, or with every Joystick if
+// it is AnyJoystick, until f returns true. It returns whether f did.
+func forEachJoystick(joystick Joystick, f func(js Joystick) bool) bool {
+	if joystick != AnyJoystick {
+		return f(joystick)
+	}
+	for js := Joystick1; js <= JoystickLast; js++ {
+		if f(js) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindingsJSON is the JSON form of Bindings, with Buttons by name.
+type bindingsJSON struct {
+	Actions map[string][]string `json:"actions,omitempty"`
+	Axes    map[string]axisJSON `json:"axes,omitempty"`
+}
+
+type axisJSON struct {
+	Negative     []string `json:"negative"`
+	Positive     []string `json:"positive"`
+	Gamepad      []string `json:"gamepad,omitempty"`
+	NegativeWins bool     `json:"negativeWins,omitempty"`
+}
+
+// MarshalJSON encodes the Bindings with Buttons by name. The Buttons
+// and gamepad buttons of an action are listed together, such as
+// ["Space", "Joystick1:Button0"].
+func (b *Bindings) MarshalJSON() ([]byte, error) {
+	out := bindingsJSON{
+		Actions: make(map[string][]string, len(b.actions)),
+		Axes:    make(map[string]axisJSON, len(b.axes)),
+	}
+	for _, name := range b.Actions() {
+		names := buttonNames(b.actions[name])
+		for _, button := range b.gamepadActions[name] {
+			names = append(names, button.String())
+		}
+		out.Actions[name] = names
+	}
+	for name, axis := range b.axes {
+		gamepad := make([]string, len(axis.Gamepad))
+		for i, gamepadAxis := range axis.Gamepad {
+			gamepad[i] = gamepadAxis.String()
+		}
+		out.Axes[name] = axisJSON{
+			Negative:     buttonNames(axis.Negative),
+			Positive:     buttonNames(axis.Positive),
+			Gamepad:      gamepad,
+			NegativeWins: axis.NegativeWins,
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes Bindings encoded by MarshalJSON. The actions and
+// axes that are decoded replace those with the same name, others are kept.
+func (b *Bindings) UnmarshalJSON(data []byte) error {
+	var in bindingsJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	// parse everything before binding so that a bad button binds nothing
+	actions := make(map[string][]pixelgl.Button, len(in.Actions))
+	gamepadActions := make(map[string][]GamepadButton, len(in.Actions))
+	for name, names := range in.Actions {
+		buttons, gamepadButtons, err := parseActionButtons(names)
+		if err != nil {
+			return errors.Wrapf(err, "unable to bind action %s", name)
+		}
+		actions[name] = buttons
+		gamepadActions[name] = gamepadButtons
+	}
+	axes := make(map[string]AxisBinding, len(in.Axes))
+	for name, axis := range in.Axes {
+		negative, err := parseButtons(axis.Negative)
+		if err != nil {
+			return errors.Wrapf(err, "unable to bind axis %s", name)
+		}
+		positive, err := parseButtons(axis.Positive)
+		if err != nil {
+			return errors.Wrapf(err, "unable to bind axis %s", name)
+		}
+		gamepad, err := parseGamepadAxes(axis.Gamepad)
+		if err != nil {
+			return errors.Wrapf(err, "unable to bind axis %s", name)
+		}
+		axes[name] = AxisBinding{Negative: negative, Positive: positive, Gamepad: gamepad, NegativeWins: axis.NegativeWins}
+	}
+
+	if b.actions == nil {
+		b.actions = make(map[string][]pixelgl.Button)
+	}
+	if b.gamepadActions == nil {
+		b.gamepadActions = make(map[string][]GamepadButton)
+	}
+	if b.axes == nil {
+		b.axes = make(map[string]AxisBinding)
+	}
+	for name, buttons := range actions {
+		delete(b.actions, name)
+		delete(b.gamepadActions, name)
+		if gamepadButtons := gamepadActions[name]; len(gamepadButtons) > 0 {
+			b.gamepadActions[name] = gamepadButtons
+		}
+		if len(buttons) > 0 || len(gamepadActions[name]) == 0 {
+			b.actions[name] = buttons
+		}
+	}
+	for name, axis := range axes {
+		b.axes[name] = axis
+	}
+	return nil
+}
+
+// Save writes the Bindings as JSON.
+func (b *Bindings) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return errors.Wrap(encoder.Encode(b), "unable to save bindings")
+}
+
+// Load reads Bindings written by Save. The actions and axes that are
+// read replace those with the same name, so that saved controls can be
+// loaded on top of a game's default Bindings.
+func (b *Bindings) Load(r io.Reader) error {
+	return errors.Wrap(json.NewDecoder(r).Decode(b), "unable to load bindings")
+}
+
+// buttonNames returns the names of Buttons.
+func buttonNames(buttons []pixelgl.Button) []string {
+	names := make([]string, len(buttons))
+	for i, button := range buttons {
+		names[i] = button.String()
+	}
+	return names
+}
+
+// parseButtons returns the Buttons with the given names.
+func parseButtons(names []string) ([]pixelgl.Button, error) {
+	buttons := make([]pixelgl.Button, len(names))
+	for i, name := range names {
+		button, err := ParseButton(name)
+		if err != nil {
+			return nil, err
+		}
+		buttons[i] = button
+	}
+	return buttons, nil
+}
+
+// parseActionButtons returns the Buttons and gamepad buttons with the given names.
+func parseActionButtons(names []string) ([]pixelgl.Button, []GamepadButton, error) {
+	var buttons []pixelgl.Button
+	var gamepadButtons []GamepadButton
+	for _, name := range names {
+		if button, err := ParseButton(name); err == nil {
+			buttons = append(buttons, button)
+			continue
+		}
+		if strings.Contains(name, ":") {
+			button, err := ParseGamepadButton(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			gamepadButtons = append(gamepadButtons, button)
+			continue
+		}
+		return nil, nil, errors.Errorf("unknown button %q", name)
+	}
+	return buttons, gamepadButtons, nil
+}
+
+// parseGamepadAxes returns the gamepad axes with the given names.
+func parseGamepadAxes(names []string) ([]GamepadAxis, error) {
+	var axes []GamepadAxis
+	for _, name := range names {
+		axis, err := ParseGamepadAxis(name)
+		if err != nil {
+			return nil, err
+		}
+		axes = append(axes, axis)
+	}
+	return axes, nil
+}
@@ -0,0 +1,222 @@
+package wo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBindings() *Bindings {
+	bindings := NewBindings()
+	bindings.BindAction("jump", pixelgl.KeySpace, pixelgl.MouseButtonLeft)
+	bindings.BindAxis("horizontal", AxisBinding{
+		Negative: []pixelgl.Button{pixelgl.KeyA, pixelgl.KeyLeft},
+		Positive: []pixelgl.Button{pixelgl.KeyD, pixelgl.KeyRight},
+	})
+	return bindings
+}
+
+func TestParseButton(t *testing.T) {
+	cases := map[string]pixelgl.Button{
+		"Space":           pixelgl.KeySpace,
+		"A":               pixelgl.KeyA,
+		"Left":            pixelgl.KeyLeft,
+		"MouseButtonLeft": pixelgl.MouseButtonLeft,
+	}
+	for name, expected := range cases {
+		button, err := ParseButton(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, button, name)
+	}
+
+	_, err := ParseButton("Invalid")
+	assert.EqualError(t, err, `unknown button "Invalid"`)
+	_, err = ParseButton("Unknown")
+	assert.EqualError(t, err, `unknown button "Unknown"`)
+}
+
+func TestJustPressedButton(t *testing.T) {
+	input := newTestInput()
+
+	_, ok := JustPressedButton(input)
+	assert.False(t, ok)
+
+	input.press(pixelgl.KeyQ)
+	button, ok := JustPressedButton(input)
+	assert.True(t, ok)
+	assert.Equal(t, pixelgl.KeyQ, button)
+}
+
+func TestBindings_actions(t *testing.T) {
+	bindings := newTestBindings()
+	input := newTestInput()
+
+	assert.False(t, bindings.Pressed(input, "jump"))
+	input.press(pixelgl.MouseButtonLeft)
+	assert.True(t, bindings.Pressed(input, "jump"))
+	assert.True(t, bindings.JustPressed(input, "jump"))
+	input.nextFrame()
+	input.release(pixelgl.MouseButtonLeft)
+	assert.False(t, bindings.Pressed(input, "jump"))
+	assert.True(t, bindings.JustReleased(input, "jump"))
+
+	assert.False(t, bindings.Pressed(input, "missing"))
+	assert.Equal(t, []string{"jump"}, bindings.Actions())
+}
+
+func TestBindings_Value(t *testing.T) {
+	bindings := newTestBindings()
+	input := newTestInput()
+
+	assert.Equal(t, 0.0, bindings.Value(input, "horizontal"))
+	input.press(pixelgl.KeyLeft)
+	assert.Equal(t, -1.0, bindings.Value(input, "horizontal"))
+	input.press(pixelgl.KeyD)
+	assert.Equal(t, 0.0, bindings.Value(input, "horizontal"))
+	input.release(pixelgl.KeyLeft)
+	assert.Equal(t, 1.0, bindings.Value(input, "horizontal"))
+
+	assert.Equal(t, 0.0, bindings.Value(input, "missing"))
+	assert.Equal(t, []string{"horizontal"}, bindings.Axes())
+}
+
+func TestBindings_Value_negativeWins(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	keys := newTestInput()
+	input := WithGamepads(keys, gamepads)
+	bindings := NewBindings()
+	bindings.BindAxis("run", AxisBinding{
+		Negative:     []pixelgl.Button{pixelgl.KeyLeft},
+		Positive:     []pixelgl.Button{pixelgl.KeyRight},
+		Gamepad:      []GamepadAxis{{Joystick: Joystick1, Axis: 0}},
+		NegativeWins: true,
+	})
+	backend.Connect(Joystick1, "stick", 0, 1)
+	gamepads.Update()
+
+	keys.press(pixelgl.KeyLeft)
+	keys.press(pixelgl.KeyRight)
+	assert.Equal(t, -1.0, bindings.Value(input, "run"))
+
+	keys.release(pixelgl.KeyLeft)
+	backend.SetAxis(Joystick1, 0, -1)
+	gamepads.Update()
+	assert.Equal(t, -1.0, bindings.Value(input, "run"), "a gamepad left wins too")
+
+	backend.SetAxis(Joystick1, 0, 0)
+	gamepads.Update()
+	assert.Equal(t, 1.0, bindings.Value(input, "run"))
+}
+
+func TestBindings_rebind(t *testing.T) {
+	bindings := newTestBindings()
+	input := newTestInput()
+	input.press(pixelgl.KeySpace)
+
+	bindings.BindAction("jump", pixelgl.KeyW)
+
+	assert.False(t, bindings.Pressed(input, "jump"))
+	assert.Equal(t, []pixelgl.Button{pixelgl.KeyW}, bindings.Action("jump"))
+}
+
+func TestBindings_SaveLoad(t *testing.T) {
+	saved := newTestBindings()
+	var buf bytes.Buffer
+
+	assert.NoError(t, saved.Save(&buf))
+	assert.Contains(t, buf.String(), `"MouseButtonLeft"`)
+
+	loaded := NewBindings()
+	assert.NoError(t, loaded.Load(&buf))
+	assert.Equal(t, saved, loaded)
+}
+
+func TestBindings_Load_keepsDefaults(t *testing.T) {
+	bindings := newTestBindings()
+
+	err := bindings.Load(bytes.NewBufferString(`{"actions": {"jump": ["W"], "fire": ["MouseButtonRight"]}}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []pixelgl.Button{pixelgl.KeyW}, bindings.Action("jump"))
+	assert.Equal(t, []pixelgl.Button{pixelgl.MouseButtonRight}, bindings.Action("fire"))
+	assert.Equal(t, []pixelgl.Button{pixelgl.KeyD, pixelgl.KeyRight}, bindings.Axis("horizontal").Positive)
+}
+
+func TestBindings_Load_unknownButton(t *testing.T) {
+	bindings := newTestBindings()
+
+	err := bindings.Load(bytes.NewBufferString(`{"actions": {"jump": ["W"]}, "axes": {"horizontal": {"negative": ["Nope"], "positive": []}}}`))
+
+	assert.EqualError(t, err, `unable to load bindings: unable to bind axis horizontal: unknown button "Nope"`)
+	assert.Equal(t, []pixelgl.Button{pixelgl.KeySpace, pixelgl.MouseButtonLeft}, bindings.Action("jump"))
+}
+
+func TestBindings_Load_keyUnknown(t *testing.T) {
+	bindings := newTestBindings()
+
+	err := bindings.Load(bytes.NewBufferString(`{"actions": {"jump": ["Unknown"]}}`))
+
+	assert.EqualError(t, err, `unable to load bindings: unable to bind action jump: unknown button "Unknown"`)
+	assert.Equal(t, []pixelgl.Button{pixelgl.KeySpace, pixelgl.MouseButtonLeft}, bindings.Action("jump"))
+}
+
+func TestBindings_gamepad(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	keys := newTestInput()
+	input := WithGamepads(keys, gamepads)
+	bindings := newTestBindings()
+	bindings.BindGamepadAction("jump", GamepadButton{Joystick: AnyJoystick, Button: 0})
+	bindings.BindAxis("horizontal", AxisBinding{
+		Negative: []pixelgl.Button{pixelgl.KeyA},
+		Positive: []pixelgl.Button{pixelgl.KeyD},
+		Gamepad:  []GamepadAxis{{Joystick: Joystick1, Axis: 0, Invert: true}},
+	})
+	backend.Connect(Joystick3, "pad", 1, 0)
+	backend.Connect(Joystick1, "stick", 0, 1)
+	gamepads.Update()
+
+	backend.SetButton(Joystick3, 0, true)
+	backend.SetAxis(Joystick1, 0, 0.6)
+	gamepads.Update()
+
+	assert.True(t, bindings.Pressed(input, "jump"))
+	assert.True(t, bindings.JustPressed(input, "jump"))
+	assert.InDelta(t, -0.5, bindings.Value(input, "horizontal"), 1e-9)
+
+	button, ok := JustPressedGamepadButton(input)
+	assert.True(t, ok)
+	assert.Equal(t, GamepadButton{Joystick: Joystick3, Button: 0}, button)
+
+	keys.press(pixelgl.KeyA)
+	assert.Equal(t, -1.0, bindings.Value(input, "horizontal"))
+}
+
+func TestBindings_SaveLoad_gamepad(t *testing.T) {
+	saved := newTestBindings()
+	saved.BindGamepadAction("jump", GamepadButton{Joystick: AnyJoystick, Button: 0})
+	saved.BindGamepadAction("pause", GamepadButton{Joystick: Joystick1, Button: 7})
+	saved.BindAxis("vertical", AxisBinding{
+		Gamepad: []GamepadAxis{{Joystick: AnyJoystick, Axis: 1, Invert: true}},
+	})
+	saved.BindAxis("run", AxisBinding{
+		Negative:     []pixelgl.Button{pixelgl.KeyLeft},
+		NegativeWins: true,
+	})
+	var buf bytes.Buffer
+
+	assert.NoError(t, saved.Save(&buf))
+	assert.Contains(t, buf.String(), `"AnyJoystick:Button0"`)
+	assert.Contains(t, buf.String(), `"-AnyJoystick:Axis1"`)
+
+	loaded := NewBindings()
+	assert.NoError(t, loaded.Load(&buf))
+	assert.Equal(t, []string{"jump", "pause"}, loaded.Actions())
+	assert.Equal(t, saved.Action("jump"), loaded.Action("jump"))
+	assert.Equal(t, saved.GamepadAction("jump"), loaded.GamepadAction("jump"))
+	assert.Equal(t, saved.GamepadAction("pause"), loaded.GamepadAction("pause"))
+	assert.Equal(t, saved.Axis("vertical").Gamepad, loaded.Axis("vertical").Gamepad)
+	assert.True(t, loaded.Axis("run").NegativeWins)
+}
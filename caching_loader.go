@@ -0,0 +1,487 @@
+package wo
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/golang/freetype/truetype"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/image/font"
+)
+
+var (
+	_ Loader        = &CachingLoader{}
+	_ Loader        = &LoaderScope{}
+	_ AssetReloader = &CachingLoader{}
+)
+
+// AssetReloader reloads assets whose files changed, such
+// as a CachingLoader created with NewDevLoader.
+type AssetReloader interface {
+	// Reload reloads the assets that changed and
+	// returns the names of the reloaded assets
+	Reload() ([]string, error)
+}
+
+// assetKind is the kind of asset that is cached.
+type assetKind int
+
+const (
+	assetPicture assetKind = iota
+	assetSound
+	assetFont
+)
+
+// assetKey identifies a cached asset.
+type assetKey struct {
+	kind assetKind
+	name string
+	// variant is the variant of a transformed picture or the format of a sound
+	variant string
+}
+
+// cachedAsset is a decoded asset and the number of LoaderScopes holding it.
+type cachedAsset struct {
+	value interface{}
+	refs  int
+
+	// ready is closed once the asset is decoded, and
+	// err is why it could not be decoded, if it could not
+	ready chan struct{}
+	err   error
+
+	// decode decodes the asset again when it is reloaded
+	decode func() (interface{}, error)
+	// modTime is when the file of the asset was modified
+	modTime time.Time
+	// source is followed by the SpriteSheets loaded from a
+	// picture, if the asset can be reloaded
+	source *pictureSource
+	// sprites are the Sprites loaded from a picture by each
+	// LoaderScope, if the asset can be reloaded
+	sprites map[*LoaderScope]*pixel.Sprite
+}
+
+// pictureSource is the current PictureData of a picture that can be
+// reloaded. SpriteSheets loaded from the picture switch to the
+// PictureData of its source when it changes.
+type pictureSource struct {
+	pic *pixel.PictureData
+}
+
+// decoded returns whether the asset is done decoding.
+func (a *cachedAsset) decoded() bool {
+	select {
+	case <-a.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// swap replaces the value of the asset with a reloaded value
+// and updates everything that was loaded from the asset.
+func (a *cachedAsset) swap(value interface{}) {
+	switch v := value.(type) {
+	case *pixel.PictureData:
+		for _, sprite := range a.sprites {
+			sprite.Set(v, v.Bounds())
+		}
+		if a.source != nil {
+			a.source.pic = v
+		}
+		a.value = v
+	case *Sound:
+		a.value.(*Sound).replace(v)
+	case *truetype.Font:
+		*a.value.(*truetype.Font) = *v
+	}
+}
+
+// CachingLoader is a Loader that decodes each asset once and shares
+// it with everything that loads it again. Sprites and SpriteSheets of
+// the same image share their PictureData, FontFaces of the same Font
+// share the parsed Font, and Sounds are shared as is.
+//
+// Assets are held by LoaderScopes. A Scene can load its assets with its
+// own Scope and release that Scope when it is disposed. An asset is freed
+// once no Scope holds it, so assets shared with the next Scene are not
+// decoded again. Assets loaded from the CachingLoader itself are held
+// until it is Released.
+//
+// Transformed images are only cached as variants of an image, see
+// SpriteVariant. Images loaded with Sprite or SpriteSheet and any
+// ImageTransformers are decoded every time and not cached.
+//
+// CachingLoaders created with NewDevLoader can Reload assets
+// whose files changed while the game is running. A LoaderScope of
+// such a CachingLoader loads the same Sprite every time it loads
+// the same image, so that Sprites can be updated when it is reloaded.
+//
+// A CachingLoader is safe to use from several goroutines, such as
+// AsyncSceneFactories.
+type CachingLoader struct {
+	load *simpleLoader
+	root *LoaderScope
+	// modTime gets the modification time of the file of an
+	// asset, or is nil if assets cannot be reloaded
+	modTime func(name string) (time.Time, error)
+
+	mu     sync.Mutex
+	assets map[assetKey]*cachedAsset
+}
+
+// NewCachingLoaderFromByteReader creates a new CachingLoader from a
+// function that can acquire bytes by name. Assets are decoded the same
+// way as by NewLoaderFromByteReader.
+func NewCachingLoaderFromByteReader(reader ByteReader) *CachingLoader {
+	return NewCachingLoader(func(name string) (io.Reader, error) {
+		b, err := reader(name)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(b), nil
+	})
+}
+
+// NewCachingLoader creates a new CachingLoader from a function that can
+// acquire an io.Reader by name. Assets are decoded the same way as by
+// NewLoader.
+func NewCachingLoader(reader AssetReader) *CachingLoader {
+	c := &CachingLoader{
+		load:   &simpleLoader{reader: reader},
+		assets: make(map[assetKey]*cachedAsset),
+	}
+	c.root = c.Scope()
+	return c
+}
+
+// NewDevLoader creates a CachingLoader for development that reads
+// assets from files in a directory, see NewDirReader, and that can
+// Reload them when the files change. Artists can see their changes
+// to assets without restarting the game, such as with
+// World.SetAssetReloader.
+func NewDevLoader(dir string) *CachingLoader {
+	c := NewCachingLoader(NewDirReader(dir))
+	c.modTime = dirModTime(dir)
+	return c
+}
+
+// Scope creates a LoaderScope that holds the assets loaded with it.
+func (c *CachingLoader) Scope() *LoaderScope {
+	return &LoaderScope{
+		cache: c,
+		held:  make(map[assetKey]bool),
+	}
+}
+
+// Len returns the number of assets that are cached.
+func (c *CachingLoader) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.assets)
+}
+
+// Release releases the assets loaded from the CachingLoader itself.
+// They are freed unless a LoaderScope holds them.
+func (c *CachingLoader) Release() {
+	c.root.Release()
+}
+
+func (c *CachingLoader) Sprite(name string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+	return c.root.Sprite(name, transforms...)
+}
+
+func (c *CachingLoader) SpriteSheet(name string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
+	return c.root.SpriteSheet(name, opts, transforms...)
+}
+
+// SpriteVariant loads a Sprite like LoaderScope.SpriteVariant.
+func (c *CachingLoader) SpriteVariant(name, variant string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+	return c.root.SpriteVariant(name, variant, transforms...)
+}
+
+// SpriteSheetVariant loads a SpriteSheet like LoaderScope.SpriteSheetVariant.
+func (c *CachingLoader) SpriteSheetVariant(name, variant string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
+	return c.root.SpriteSheetVariant(name, variant, opts, transforms...)
+}
+
+func (c *CachingLoader) Sound(format string, name string) (*Sound, error) {
+	return c.root.Sound(format, name)
+}
+
+func (c *CachingLoader) Font(name string) (*truetype.Font, error) {
+	return c.root.Font(name)
+}
+
+func (c *CachingLoader) FontFace(name string, size float64) (font.Face, error) {
+	return c.root.FontFace(name, size)
+}
+
+// acquire returns a cached asset, decoding it first if it is not
+// cached, and makes the LoaderScope hold it. If live is not nil, it
+// returns what live creates from the asset instead, such as a Sprite.
+//
+// Assets are decoded without holding the lock, so that different assets
+// load in parallel. Loads of an asset that is being decoded wait for it.
+func (c *CachingLoader) acquire(scope *LoaderScope, key assetKey, decode func() (interface{}, error), live func(asset *cachedAsset) interface{}) (interface{}, error) {
+	c.mu.Lock()
+	asset, ok := c.assets[key]
+	if !ok {
+		asset = &cachedAsset{ready: make(chan struct{}), decode: decode}
+		c.assets[key] = asset
+		c.mu.Unlock()
+		c.decode(key, asset)
+	} else {
+		c.mu.Unlock()
+	}
+	<-asset.ready
+	if asset.err != nil {
+		return nil, asset.err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !scope.held[key] {
+		scope.held[key] = true
+		asset.refs++
+	}
+	if live == nil {
+		return asset.value, nil
+	}
+	return live(asset), nil
+}
+
+// decode decodes an asset that was added to the cache. An
+// asset that cannot be decoded is removed from the cache.
+func (c *CachingLoader) decode(key assetKey, asset *cachedAsset) {
+	var modTime time.Time
+	if c.modTime != nil {
+		// a file that cannot be read fails to decode below
+		modTime, _ = c.modTime(key.name)
+	}
+	value, err := asset.decode()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer close(asset.ready)
+	if err != nil {
+		asset.err = err
+		delete(c.assets, key)
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"name":    key.name,
+		"variant": key.variant,
+	}).Debug("asset cached")
+	asset.value = value
+	asset.modTime = modTime
+	if c.modTime != nil {
+		if pic, ok := value.(*pixel.PictureData); ok {
+			asset.source = &pictureSource{pic: pic}
+			asset.sprites = make(map[*LoaderScope]*pixel.Sprite)
+		}
+	}
+}
+
+// release makes a LoaderScope stop holding its assets
+// and frees the assets no other LoaderScope holds.
+func (c *CachingLoader) release(scope *LoaderScope) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range scope.held {
+		asset := c.assets[key]
+		delete(asset.sprites, scope)
+		asset.refs--
+		if asset.refs == 0 {
+			logrus.WithFields(logrus.Fields{
+				"name":    key.name,
+				"variant": key.variant,
+			}).Debug("asset freed")
+			delete(c.assets, key)
+		}
+	}
+	scope.held = make(map[assetKey]bool)
+}
+
+// picture loads the PictureData of an image, caching it as a variant
+// unless it is transformed without a variant, and returns the Sprite
+// or SpriteSheet live creates from it.
+func (c *CachingLoader) picture(scope *LoaderScope, name, variant string, transforms []ImageTransformer, live func(asset *cachedAsset) interface{}) (interface{}, error) {
+	if variant == "" && len(transforms) > 0 {
+		pic, err := c.load.picture(name, transforms)
+		if err != nil {
+			return nil, err
+		}
+		return live(&cachedAsset{value: pic}), nil
+	}
+	key := assetKey{kind: assetPicture, name: name, variant: variant}
+	decode := func() (interface{}, error) {
+		return c.load.picture(name, transforms)
+	}
+	return c.acquire(scope, key, decode, live)
+}
+
+// Reload decodes the cached assets whose files changed since they were
+// loaded and swaps them into the Sprites, SpriteSheets, Sounds and Fonts
+// that were loaded before, returning the names of the reloaded assets.
+// Text that was already drawn into a text.Atlas keeps the old Font, but
+// new FontFaces use the reloaded Font.
+//
+// Assets that fail to decode, such as files that are still being written,
+// keep their old data until their file changes again. The first error is
+// returned after the other assets are reloaded.
+//
+// Reload only reloads assets of CachingLoaders created with NewDevLoader.
+// It should be called between frames on the goroutine that draws them.
+func (c *CachingLoader) Reload() ([]string, error) {
+	if c.modTime == nil {
+		return nil, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]assetKey, 0, len(c.assets))
+	for key := range c.assets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].variant < keys[j].variant
+	})
+
+	var reloaded []string
+	var firstErr error
+	for _, key := range keys {
+		asset := c.assets[key]
+		if !asset.decoded() || asset.err != nil {
+			continue
+		}
+		modTime, err := c.modTime(key.name)
+		if err != nil || modTime.Equal(asset.modTime) {
+			// deleted files keep their old data
+			continue
+		}
+		asset.modTime = modTime
+		value, err := asset.decode()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "unable to reload %s", key.name)
+			}
+			continue
+		}
+		asset.swap(value)
+		logrus.WithFields(logrus.Fields{
+			"name":    key.name,
+			"variant": key.variant,
+		}).Debug("asset reloaded")
+		if len(reloaded) == 0 || reloaded[len(reloaded)-1] != key.name {
+			reloaded = append(reloaded, key.name)
+		}
+	}
+	return reloaded, firstErr
+}
+
+// LoaderScope is a Loader that loads assets from a CachingLoader and
+// holds them until it is released. A LoaderScope can be used again
+// after it is released.
+type LoaderScope struct {
+	cache *CachingLoader
+	// held is guarded by the mutex of the cache
+	held map[assetKey]bool
+}
+
+// Release stops holding the assets loaded with the LoaderScope.
+// They are freed unless another LoaderScope holds them.
+func (s *LoaderScope) Release() {
+	s.cache.release(s)
+}
+
+func (s *LoaderScope) Sprite(name string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+	return s.SpriteVariant(name, "", transforms...)
+}
+
+// SpriteSheet loads a SpriteSheet that shares its PictureData, but
+// not its current frame, with other SpriteSheets of the same image.
+func (s *LoaderScope) SpriteSheet(name string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
+	return s.SpriteSheetVariant(name, "", opts, transforms...)
+}
+
+// SpriteVariant loads a Sprite of an image transformed by
+// ImageTransformers and caches the transformed image as a variant of
+// the image, such as "small" for an image that is resized. Every load
+// of the same variant of an image must use the same ImageTransformers.
+func (s *LoaderScope) SpriteVariant(name, variant string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+	sprite, err := s.cache.picture(s, name, variant, transforms, func(asset *cachedAsset) interface{} {
+		pic := asset.value.(*pixel.PictureData)
+		if asset.sprites == nil {
+			return pixel.NewSprite(pic, pic.Bounds())
+		}
+		sprite, ok := asset.sprites[s]
+		if !ok {
+			sprite = pixel.NewSprite(pic, pic.Bounds())
+			asset.sprites[s] = sprite
+		}
+		return sprite
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sprite.(*pixel.Sprite), nil
+}
+
+// SpriteSheetVariant loads a SpriteSheet of a variant of an image like
+// SpriteVariant. The SpriteSheet shares its PictureData with other
+// SpriteSheets of the same variant.
+func (s *LoaderScope) SpriteSheetVariant(name, variant string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
+	sheet, err := s.cache.picture(s, name, variant, transforms, func(asset *cachedAsset) interface{} {
+		sheet := NewSpriteSheet(asset.value.(*pixel.PictureData), opts)
+		sheet.source = asset.source
+		return sheet
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sheet.(*SpriteSheet), nil
+}
+
+func (s *LoaderScope) Sound(format string, name string) (*Sound, error) {
+	value, err := s.cache.acquire(s, assetKey{kind: assetSound, name: name, variant: format}, func() (interface{}, error) {
+		return s.cache.load.Sound(format, name)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*Sound), nil
+}
+
+func (s *LoaderScope) Font(name string) (*truetype.Font, error) {
+	value, err := s.cache.acquire(s, assetKey{kind: assetFont, name: name}, func() (interface{}, error) {
+		return s.cache.load.Font(name)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*truetype.Font), nil
+}
+
+// FontFace creates a new FontFace for a shared Font. FontFaces
+// are not shared because they cache glyphs as they are used.
+func (s *LoaderScope) FontFace(name string, size float64) (font.Face, error) {
+	f, err := s.Font(name)
+	if err != nil {
+		return nil, err
+	}
+	return newFontFace(f, size), nil
+}
@@ -0,0 +1,389 @@
+package wo
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"sync/atomic"
+	"testing"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/pixel"
+	"github.com/golang/freetype/truetype"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestCachingLoader_Sprite_shared(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	a, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+	b, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, reads["test.img"])
+	assert.True(t, a.Picture() == b.Picture())
+	assert.Equal(t, 1, loader.Len())
+}
+
+func TestCachingLoader_SpriteSheet_sharesPicture(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+	opts := SpriteSheetOptions{Width: 4, Height: 2, Columns: 1, Rows: 2}
+
+	a, err := loader.SpriteSheet("test.img", opts)
+	assert.Nil(t, err)
+	b, err := loader.SpriteSheet("test.img", opts)
+	assert.Nil(t, err)
+	sprite, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, reads["test.img"])
+	assert.True(t, a.Sprite().Picture() == sprite.Picture())
+	a.SetFrame(1)
+	assert.NotEqual(t, a.Sprite().Frame(), b.Sprite().Frame())
+}
+
+func TestCachingLoader_SpriteVariant(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	small, err := loader.SpriteVariant("test.img", "small", ResizeTransformer(2, 2))
+	assert.Nil(t, err)
+	again, err := loader.SpriteVariant("test.img", "small", ResizeTransformer(2, 2))
+	assert.Nil(t, err)
+	plain, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, reads["test.img"])
+	assert.True(t, small.Picture() == again.Picture())
+	assert.Equal(t, 2.0, small.Frame().W())
+	assert.Equal(t, 4.0, plain.Frame().W())
+}
+
+func TestCachingLoader_Sprite_transformed(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	_, err := loader.Sprite("test.img", ResizeTransformer(2, 2))
+	assert.Nil(t, err)
+	_, err = loader.Sprite("test.img", ResizeTransformer(2, 2))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, reads["test.img"])
+	assert.Equal(t, 0, loader.Len())
+}
+
+func TestCachingLoader_parallel(t *testing.T) {
+	slow := make(chan struct{})
+	var slowReads int32
+	loader := NewCachingLoaderFromByteReader(func(name string) ([]byte, error) {
+		if name == "slow.img" {
+			atomic.AddInt32(&slowReads, 1)
+			<-slow
+		}
+		return testImageBytes, nil
+	})
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := loader.Sprite("slow.img")
+			done <- err
+		}()
+	}
+	// other assets load while the slow one is decoding
+	_, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+
+	close(slow)
+	assert.Nil(t, <-done)
+	assert.Nil(t, <-done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&slowReads))
+	assert.Equal(t, 2, loader.Len())
+}
+
+func TestCachingLoader_Font_shared(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	a, err := loader.Font("font.ttf")
+	assert.Nil(t, err)
+	face, err := loader.FontFace("font.ttf", 12)
+	assert.Nil(t, err)
+	defer face.Close()
+	b, err := loader.Font("font.ttf")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, reads["font.ttf"])
+	assert.True(t, a == b)
+	assert.NotNil(t, face)
+}
+
+func TestCachingLoader_Sound_shared(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	a, err := loader.Sound("wav", "sound.wav")
+	assert.Nil(t, err)
+	b, err := loader.Sound("wav", "sound.wav")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, reads["sound.wav"])
+	assert.True(t, a == b)
+}
+
+func TestCachingLoader_error(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+
+	_, err := loader.Sprite("missing.img")
+	assert.Error(t, err)
+	_, err = loader.Sprite("missing.img")
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, reads["missing.img"])
+	assert.Equal(t, 0, loader.Len())
+}
+
+func TestLoaderScope_Release(t *testing.T) {
+	reads, loader := newCountingCachingLoader()
+	title := loader.Scope()
+	game := loader.Scope()
+
+	_, err := title.Font("font.ttf")
+	assert.Nil(t, err)
+	_, err = title.Sprite("test.img")
+	assert.Nil(t, err)
+	_, err = game.Sprite("test.img")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, loader.Len())
+
+	// the image is still held by the game scope
+	title.Release()
+	assert.Equal(t, 1, loader.Len())
+	_, err = game.Sprite("test.img")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, reads["test.img"])
+
+	game.Release()
+	assert.Equal(t, 0, loader.Len())
+
+	// released scopes can be used again
+	_, err = title.Sprite("test.img")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, reads["test.img"])
+	title.Release()
+	assert.Equal(t, 0, loader.Len())
+}
+
+func TestWorld_Run_sharesAssets(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		reads, loader := newCountingCachingLoader()
+		newScene := func(results ...SceneResult) (Scene, error) {
+			scene := &scopedScene{testScene: newTestScene("scene", results...), scope: loader.Scope()}
+			_, err := scene.scope.Sprite("test.img")
+			return scene, err
+		}
+		scenes := map[string]SceneFactory{
+			"title": func(canvas Canvas) (Scene, error) {
+				return newScene(testResultA)
+			},
+		}
+		game := func(canvas Canvas) (Scene, error) {
+			return newScene(SceneResultWindowClosed)
+		}
+		if !async {
+			scenes["game"] = game
+		}
+		world := NewHeadlessWorld(NewHeadlessWindow(1, 1, nil), scenes)
+		if async {
+			world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+				return game(canvas)
+			})
+		}
+		world.Route("title", testResultA, "game")
+
+		err := world.Run("title")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reads["test.img"], "async: %v", async)
+		assert.Equal(t, 0, loader.Len(), "async: %v", async)
+	}
+}
+
+func TestLoaderScope_Release_heldByLoader(t *testing.T) {
+	_, loader := newCountingCachingLoader()
+	scope := loader.Scope()
+
+	_, err := loader.Sound("wav", "sound.wav")
+	assert.Nil(t, err)
+	_, err = scope.Sound("wav", "sound.wav")
+	assert.Nil(t, err)
+
+	scope.Release()
+	assert.Equal(t, 1, loader.Len())
+
+	loader.Release()
+	assert.Equal(t, 0, loader.Len())
+}
+
+func TestCachingLoader_Reload_pictures(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	writeTestFile(t, dir, "img/a.png", newTestPng(t, 4, 4))
+	loader := NewDevLoader(dir)
+
+	sprite, err := loader.Sprite("img/a.png")
+	assert.Nil(t, err)
+	sheet, err := loader.SpriteSheet("img/a.png", SpriteSheetOptions{Width: 4, Height: 2, Columns: 1, Rows: 2})
+	assert.Nil(t, err)
+	sheet.SetFrame(1)
+	reloaded, err := loader.Reload()
+	assert.Nil(t, err)
+	assert.Empty(t, reloaded)
+
+	writeTestFile(t, dir, "img/a.png", newTestPng(t, 8, 4))
+	reloaded, err = loader.Reload()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"img/a.png"}, reloaded)
+	assert.Equal(t, pixel.R(0, 0, 8, 4), sprite.Frame())
+	assert.True(t, sprite.Picture() == sheet.Sprite().Picture())
+	assert.Equal(t, pixel.R(0, 0, 4, 2), sheet.Bounds(), "the current frame is kept")
+
+	// sprites loaded after a reload share the reloaded picture
+	again, err := loader.Sprite("img/a.png")
+	assert.Nil(t, err)
+	assert.True(t, sprite.Picture() == again.Picture())
+}
+
+func TestCachingLoader_Reload_spritesPerScope(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	writeTestFile(t, dir, "a.png", newTestPng(t, 4, 4))
+	loader := NewDevLoader(dir)
+	scope := loader.Scope()
+
+	sprite, err := scope.Sprite("a.png")
+	assert.Nil(t, err)
+	again, err := scope.Sprite("a.png")
+	assert.Nil(t, err)
+	other, err := loader.Sprite("a.png")
+	assert.Nil(t, err)
+
+	assert.True(t, sprite == again, "a scope reuses its Sprite")
+	assert.False(t, sprite == other)
+
+	scope.Release()
+	writeTestFile(t, dir, "a.png", newTestPng(t, 8, 4))
+	_, err = loader.Reload()
+	assert.Nil(t, err)
+
+	assert.Equal(t, pixel.R(0, 0, 4, 4), sprite.Frame(), "released Sprites are forgotten")
+	assert.Equal(t, pixel.R(0, 0, 8, 4), other.Frame())
+}
+
+func TestCachingLoader_Reload_error(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	writeTestFile(t, dir, "a.png", newTestPng(t, 4, 4))
+	writeTestFile(t, dir, "b.png", newTestPng(t, 4, 4))
+	loader := NewDevLoader(dir)
+	a, err := loader.Sprite("a.png")
+	assert.Nil(t, err)
+	b, err := loader.Sprite("b.png")
+	assert.Nil(t, err)
+
+	// a is still being written while b is done
+	writeTestFile(t, dir, "a.png", []byte("garbage"))
+	writeTestFile(t, dir, "b.png", newTestPng(t, 2, 2))
+	reloaded, err := loader.Reload()
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"b.png"}, reloaded)
+	assert.Equal(t, pixel.R(0, 0, 4, 4), a.Frame())
+	assert.Equal(t, pixel.R(0, 0, 2, 2), b.Frame())
+
+	// the broken file is not reloaded until it changes
+	reloaded, err = loader.Reload()
+	assert.Nil(t, err)
+	assert.Empty(t, reloaded)
+
+	writeTestFile(t, dir, "a.png", newTestPng(t, 8, 8))
+	reloaded, err = loader.Reload()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a.png"}, reloaded)
+	assert.Equal(t, pixel.R(0, 0, 8, 8), a.Frame())
+}
+
+func TestCachingLoader_Reload_soundsAndFonts(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	writeTestFile(t, dir, "sound.wav", newTestWav(44100))
+	writeTestFile(t, dir, "font.ttf", goregular.TTF)
+	loader := NewDevLoader(dir)
+	sound, err := loader.Sound("wav", "sound.wav")
+	assert.Nil(t, err)
+	f, err := loader.Font("font.ttf")
+	assert.Nil(t, err)
+	assert.Equal(t, "Go Regular", f.Name(truetype.NameIDFontFullName))
+
+	writeTestFile(t, dir, "sound.wav", newTestWav(22050))
+	writeTestFile(t, dir, "font.ttf", gobold.TTF)
+	reloaded, err := loader.Reload()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"font.ttf", "sound.wav"}, reloaded)
+	_, format := sound.stream()
+	assert.Equal(t, beep.SampleRate(22050), format.SampleRate)
+	assert.Equal(t, "Go Bold", f.Name(truetype.NameIDFontFullName))
+}
+
+func TestCachingLoader_Reload_notDev(t *testing.T) {
+	_, loader := newCountingCachingLoader()
+	_, err := loader.Sprite("test.img")
+	assert.Nil(t, err)
+
+	reloaded, err := loader.Reload()
+
+	assert.Nil(t, err)
+	assert.Empty(t, reloaded)
+}
+
+// newTestPng encodes a transparent png image of a size.
+func newTestPng(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	if err := png.Encode(&b, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// newCountingCachingLoader creates a CachingLoader of a test image, a
+// font and a sound that counts how many times each asset is read.
+func newCountingCachingLoader() (map[string]int, *CachingLoader) {
+	reads := make(map[string]int)
+	assets := map[string][]byte{
+		"test.img":  testImageBytes,
+		"font.ttf":  goregular.TTF,
+		"sound.wav": newTestWav(44100),
+	}
+	loader := NewCachingLoaderFromByteReader(func(name string) ([]byte, error) {
+		reads[name]++
+		b, ok := assets[name]
+		if !ok {
+			return nil, errors.Errorf("asset %s not found", name)
+		}
+		return b, nil
+	})
+	return reads, loader
+}
+
+// scopedScene is a testScene that releases its LoaderScope when it is disposed.
+type scopedScene struct {
+	*testScene
+	scope *LoaderScope
+}
+
+func (s *scopedScene) Dispose() {
+	s.scope.Release()
+}
@@ -0,0 +1,30 @@
+package wo
+
+import (
+	"image/color"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// Canvas is the surface Scenes are drawn onto. A Canvas is a
+// pixel.BasicTarget that can be cleared and can itself be drawn
+// onto another pixel.Target, like a sprite centered at the origin.
+//
+// *pixelgl.Canvas is the Canvas used when a World has a window.
+// ImageCanvas is a Canvas that does not require OpenGL.
+type Canvas interface {
+	pixel.BasicTarget
+
+	// Bounds returns the rectangle of the Canvas.
+	Bounds() pixel.Rect
+
+	// Clear fills the whole Canvas with a single color.
+	Clear(c color.Color)
+
+	// Draw draws the content of the Canvas onto another Target,
+	// transformed by the given Matrix.
+	Draw(t pixel.Target, matrix pixel.Matrix)
+}
+
+var _ Canvas = &pixelgl.Canvas{}
@@ -1,13 +1,31 @@
 package wo
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
-type clock interface {
+// Clock is the source of time for a World and an FpsLimiter.
+// Replacing the system clock makes it possible to run Scenes
+// with deterministic frame times, or faster or slower than
+// real time.
+type Clock interface {
+	// Now returns the current time.
 	Now() time.Time
-	Sleep(time.Duration)
+
+	// Sleep waits for a duration to pass. Durations
+	// of zero or less return immediately.
+	Sleep(duration time.Duration)
+
+	// Since returns the time elapsed since t.
 	Since(t time.Time) time.Duration
 }
 
+// NewSystemClock returns the Clock that tells real time.
+func NewSystemClock() Clock {
+	return &systemClock{}
+}
+
 type systemClock struct {
 }
 
@@ -22,3 +40,121 @@ func (s *systemClock) Now() time.Time {
 func (s *systemClock) Since(t time.Time) time.Duration {
 	return time.Since(t)
 }
+
+// ManualClock is a Clock whose time only passes when it is told to,
+// either with Advance or with Sleep, which returns immediately. A World
+// with a ManualClock updates every frame with a time-delta of exactly
+// one frame at its fps limit.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+var _ Clock = &ManualClock{}
+
+// NewManualClock creates a ManualClock starting at the zero time.
+func NewManualClock() *ManualClock {
+	return &ManualClock{}
+}
+
+// Now returns the current time of the clock.
+func (m *ManualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Sleep advances the clock by the duration.
+func (m *ManualClock) Sleep(duration time.Duration) {
+	if duration > 0 {
+		m.Advance(duration)
+	}
+}
+
+// Since returns the time elapsed on the clock since t.
+func (m *ManualClock) Since(t time.Time) time.Duration {
+	return m.Now().Sub(t)
+}
+
+// Advance moves the clock forward by the duration.
+func (m *ManualClock) Advance(duration time.Duration) {
+	m.mu.Lock()
+	m.now = m.now.Add(duration)
+	m.mu.Unlock()
+}
+
+// ScaledClock is a Clock that runs faster or slower than another Clock.
+type ScaledClock struct {
+	mu     sync.Mutex
+	clock  Clock
+	scale  float64
+	origin time.Time
+	start  time.Time
+}
+
+var _ Clock = &ScaledClock{}
+
+// NewScaledClock creates a Clock that runs scale times as fast as
+// another Clock. An FpsLimiter using it waits for frames in scaled
+// time, so frames limited by it come scale times as often, while
+// frames limited by vsync get time-deltas scale times as long.
+func NewScaledClock(clock Clock, scale float64) *ScaledClock {
+	now := clock.Now()
+	return &ScaledClock{
+		clock:  clock,
+		scale:  scale,
+		origin: now,
+		start:  now,
+	}
+}
+
+// SetScale changes how many times as fast as the underlying
+// Clock this Clock runs, starting now.
+func (s *ScaledClock) SetScale(scale float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start = s.now()
+	s.origin = s.clock.Now()
+	s.scale = scale
+}
+
+// Scale returns how many times as fast as the
+// underlying Clock this Clock runs.
+func (s *ScaledClock) Scale() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scale
+}
+
+// Now returns the scaled time.
+func (s *ScaledClock) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now()
+}
+
+// Sleep sleeps on the underlying Clock until the duration has passed
+// in scaled time. No time passes while the Clock is paused at a scale
+// of 0, so it sleeps for the duration in the underlying Clock's time
+// instead, which keeps an FpsLimiter waiting between frames.
+func (s *ScaledClock) Sleep(duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+	scale := s.Scale()
+	if scale <= 0 {
+		s.clock.Sleep(duration)
+		return
+	}
+	s.clock.Sleep(time.Duration(float64(duration) / scale))
+}
+
+// Since returns the scaled time elapsed since t.
+func (s *ScaledClock) Since(t time.Time) time.Duration {
+	return s.Now().Sub(t)
+}
+
+func (s *ScaledClock) now() time.Time {
+	elapsed := s.clock.Since(s.origin)
+	return s.start.Add(time.Duration(float64(elapsed) * s.scale))
+}
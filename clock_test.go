@@ -52,3 +52,61 @@ func TestSystemClock_Sleep(t *testing.T) {
 
 	assert.InEpsilon(t, sleepTime, float64(since), epsilon, "reported sleep time %v not close enough to zero ", since)
 }
+
+func TestManualClock(t *testing.T) {
+	clock := NewManualClock()
+	start := clock.Now()
+
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Second, clock.Since(start))
+
+	clock.Sleep(2 * time.Second)
+	assert.Equal(t, 3*time.Second, clock.Since(start))
+
+	clock.Sleep(-time.Second)
+	assert.Equal(t, 3*time.Second, clock.Since(start))
+}
+
+func TestScaledClock_Now(t *testing.T) {
+	manual := NewManualClock()
+	clock := NewScaledClock(manual, 2)
+	start := clock.Now()
+
+	manual.Advance(time.Second)
+
+	assert.Equal(t, 2*time.Second, clock.Since(start))
+	assert.Equal(t, 2.0, clock.Scale())
+}
+
+func TestScaledClock_SetScale(t *testing.T) {
+	manual := NewManualClock()
+	clock := NewScaledClock(manual, 2)
+	start := clock.Now()
+	manual.Advance(time.Second)
+
+	clock.SetScale(0.5)
+	manual.Advance(time.Second)
+
+	assert.Equal(t, 2500*time.Millisecond, clock.Since(start))
+}
+
+func TestScaledClock_Sleep(t *testing.T) {
+	manual := NewManualClock()
+	clock := NewScaledClock(manual, 4)
+	start := manual.Now()
+
+	clock.Sleep(time.Second)
+
+	assert.Equal(t, 250*time.Millisecond, manual.Since(start))
+}
+
+func TestScaledClock_Sleep_paused(t *testing.T) {
+	manual := NewManualClock()
+	clock := NewScaledClock(manual, 0)
+	start := manual.Now()
+
+	clock.Sleep(time.Second)
+
+	assert.Equal(t, time.Second, manual.Since(start))
+	assert.Equal(t, start, clock.Now())
+}
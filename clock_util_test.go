@@ -56,3 +56,38 @@ func (c *fakeClock) ElapsedDuration() time.Duration {
 func (c *fakeClock) ElapsedSeconds() float64 {
 	return c.now.Sub(time.Time{}).Seconds()
 }
+
+// overshootClock is a fakeClock whose sleeps overshoot by a fixed
+// duration and whose time moves forward by a tick whenever it is
+// read, like a real clock that is busy-waited on.
+type overshootClock struct {
+	*fakeClock
+	overshoot time.Duration
+	tick      time.Duration
+	sleeps    int
+}
+
+func newOvershootClock(overshoot, tick time.Duration) *overshootClock {
+	return &overshootClock{
+		fakeClock: NewFakeClock(),
+		overshoot: overshoot,
+		tick:      tick,
+	}
+}
+
+func (c *overshootClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.sleeps++
+	c.Advance(d + c.overshoot)
+}
+
+func (c *overshootClock) Now() time.Time {
+	c.Advance(c.tick)
+	return c.fakeClock.Now()
+}
+
+func (c *overshootClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
@@ -0,0 +1,300 @@
+package wo
+
+import (
+	"math"
+
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// maxComboHistory is the number of frames a ComboDetector remembers
+// at most, such as for Combos without a time limit.
+const maxComboHistory = 600
+
+// ComboStep is a single step of a Combo, such as pressing
+// down-forward or releasing a button that was charged.
+type ComboStep struct {
+	// Direction is the set of direction Buttons that must be the only
+	// ones held down out of the Direction Buttons of every Combo of the
+	// ComboDetector. They need not be pressed in the frame of the step,
+	// so a step of only a Direction happens in every frame it is held.
+	Direction []pixelgl.Button
+	// Buttons must all be held down for the step to happen.
+	Buttons []pixelgl.Button
+	// Release makes the step happen when one of the Buttons is
+	// released instead of when the last of them is pressed.
+	Release bool
+	// Charge is how long in seconds the Buttons must have been
+	// held down together before they are released.
+	Charge float64
+}
+
+// Press is a ComboStep that happens when Buttons are held down together,
+// the frame the last of them is pressed. Press(pixelgl.KeyDown,
+// pixelgl.KeyRight) is down-forward for a player facing right.
+func Press(buttons ...pixelgl.Button) ComboStep {
+	return ComboStep{Buttons: buttons}
+}
+
+// Hold is a ComboStep that happens in every frame exactly the direction
+// Buttons are held down out of all directions, whether or not they were
+// just pressed. A quarter-circle forward and punch for a player facing
+// right is:
+//
+//	Hold(pixelgl.KeyDown)
+//	Hold(pixelgl.KeyDown, pixelgl.KeyRight)
+//	ComboStep{Direction: []pixelgl.Button{pixelgl.KeyRight}, Buttons: []pixelgl.Button{pixelgl.KeyX}}
+func Hold(direction ...pixelgl.Button) ComboStep {
+	return ComboStep{Direction: direction}
+}
+
+// ChargeRelease is a ComboStep that happens when Buttons that were held
+// down together for at least charge seconds are released.
+func ChargeRelease(charge float64, buttons ...pixelgl.Button) ComboStep {
+	return ComboStep{Buttons: buttons, Release: true, Charge: charge}
+}
+
+// Combo is a sequence of ComboSteps that must happen in order, each
+// in a later frame than the last, such as a double-tap:
+//
+//	Combo{Steps: []ComboStep{Press(pixelgl.KeyRight), Press(pixelgl.KeyRight)}, Window: 0.25}
+type Combo struct {
+	Steps []ComboStep
+	// Window is the most time in seconds between the first and the
+	// last step. Combos with a Window of 0 or less have no time limit,
+	// but only the most recent frames are remembered.
+	Window float64
+}
+
+// ComboDetector detects Combos in the Input of every frame. Combos are
+// declared once with Add and the detector is updated with the Input and
+// time delta of every Update, which timestamp the frames it remembers.
+type ComboDetector struct {
+	names  []string
+	combos map[string]*comboState
+
+	history []comboFrame
+	time    float64
+	// watched are the Buttons of all Combos
+	watched map[pixelgl.Button]bool
+	// directions are the Buttons that are a Direction in any step
+	directions map[pixelgl.Button]bool
+	// downSince is the time each watched Button that is held down was pressed
+	downSince map[pixelgl.Button]float64
+	// horizon is how long frames are remembered, or 0 to remember the most frames
+	horizon float64
+}
+
+// comboState is a Combo and when it was last detected.
+type comboState struct {
+	combo Combo
+	// fired is whether the Combo was detected in the current frame
+	fired bool
+	// last is the time of the frame the Combo was last detected in,
+	// before which its steps cannot happen again
+	last float64
+}
+
+// comboFrame is the state of the watched Buttons in one frame.
+type comboFrame struct {
+	time float64
+	// held is how long each Button that is held down or was just
+	// released had been held down for
+	held         map[pixelgl.Button]float64
+	justPressed  map[pixelgl.Button]bool
+	justReleased map[pixelgl.Button]bool
+}
+
+// NewComboDetector creates a ComboDetector without any Combos.
+func NewComboDetector() *ComboDetector {
+	return &ComboDetector{
+		combos:     make(map[string]*comboState),
+		watched:    make(map[pixelgl.Button]bool),
+		directions: make(map[pixelgl.Button]bool),
+		downSince:  make(map[pixelgl.Button]float64),
+	}
+}
+
+// Add declares a Combo by name, replacing any Combo with the same name.
+func (d *ComboDetector) Add(name string, combo Combo) {
+	if _, ok := d.combos[name]; !ok {
+		d.names = append(d.names, name)
+	}
+	d.combos[name] = &comboState{combo: combo, last: math.Inf(-1)}
+
+	d.horizon = 0
+	for _, state := range d.combos {
+		if state.combo.Window <= 0 {
+			d.horizon = 0
+			break
+		}
+		d.horizon = math.Max(d.horizon, state.combo.Window)
+	}
+	for _, step := range combo.Steps {
+		for _, button := range step.Buttons {
+			d.watched[button] = true
+		}
+		for _, button := range step.Direction {
+			d.watched[button] = true
+			d.directions[button] = true
+		}
+	}
+}
+
+// Update records the Input of a frame that lasted dt seconds
+// and detects the Combos that were completed in it.
+func (d *ComboDetector) Update(dt float64, input Input) {
+	d.time += dt
+	d.record(input)
+	for _, name := range d.names {
+		state := d.combos[name]
+		state.fired = d.detect(state)
+		if state.fired {
+			state.last = d.time
+		}
+	}
+}
+
+// Detected returns whether a Combo was completed in the last frame.
+func (d *ComboDetector) Detected(name string) bool {
+	state, ok := d.combos[name]
+	return ok && state.fired
+}
+
+// DetectedCombos returns the names of the Combos completed in
+// the last frame, in the order they were added.
+func (d *ComboDetector) DetectedCombos() []string {
+	var names []string
+	for _, name := range d.names {
+		if d.combos[name].fired {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Reset forgets all remembered frames, such as when a Scene
+// is paused, so that no Combo continues from before.
+func (d *ComboDetector) Reset() {
+	d.history = nil
+	d.downSince = make(map[pixelgl.Button]float64)
+	for _, state := range d.combos {
+		state.fired = false
+		state.last = math.Inf(-1)
+	}
+}
+
+// record remembers the state of the watched Buttons in the current frame.
+func (d *ComboDetector) record(input Input) {
+	frame := comboFrame{
+		time:         d.time,
+		held:         make(map[pixelgl.Button]float64),
+		justPressed:  make(map[pixelgl.Button]bool),
+		justReleased: make(map[pixelgl.Button]bool),
+	}
+	for button := range d.watched {
+		pressed := input.Pressed(button)
+		since, down := d.downSince[button]
+		// a button can be pressed and released within a single frame
+		if input.JustPressed(button) || (pressed && !down) {
+			since, down = d.time, true
+			d.downSince[button] = since
+			frame.justPressed[button] = true
+		}
+		if down {
+			frame.held[button] = d.time - since
+		}
+		if down && !pressed {
+			frame.justReleased[button] = true
+			delete(d.downSince, button)
+		}
+	}
+
+	d.history = append(d.history, frame)
+	start := 0
+	for start < len(d.history)-1 && (len(d.history)-start > maxComboHistory ||
+		(d.horizon > 0 && d.history[start].time < d.time-d.horizon)) {
+		start++
+	}
+	d.history = d.history[start:]
+}
+
+// detect returns whether the last step of a Combo happened in the current
+// frame with all of its earlier steps in earlier frames within its Window.
+// Each earlier step is matched to the latest frame it happened in, which
+// leaves the most time for the steps before it.
+func (d *ComboDetector) detect(state *comboState) bool {
+	steps := state.combo.Steps
+	if len(steps) == 0 {
+		return false
+	}
+	last := len(d.history) - 1
+	if !steps[len(steps)-1].happened(d.history[last], d.directions) {
+		return false
+	}
+	earliest := state.last
+	if window := state.combo.Window; window > 0 {
+		earliest = math.Max(earliest, d.time-window)
+	}
+	frame := last
+	for step := len(steps) - 2; step >= 0; step-- {
+		frame--
+		for frame >= 0 && !steps[step].happened(d.history[frame], d.directions) {
+			frame--
+		}
+		if frame < 0 || d.history[frame].time < earliest || d.history[frame].time <= state.last {
+			return false
+		}
+	}
+	return d.history[last].time > state.last
+}
+
+// happened returns whether the step happened in a frame
+// in which only its Direction out of the directions was held.
+func (s ComboStep) happened(frame comboFrame, directions map[pixelgl.Button]bool) bool {
+	if len(s.Direction) > 0 && !frame.holding(s.Direction, directions) {
+		return false
+	}
+	if len(s.Buttons) == 0 {
+		return len(s.Direction) > 0
+	}
+	edge := false
+	held := math.Inf(1)
+	for _, button := range s.Buttons {
+		duration, down := frame.held[button]
+		if !down {
+			return false
+		}
+		if s.Release && frame.justReleased[button] {
+			edge = true
+		}
+		if !s.Release {
+			if frame.justReleased[button] && !frame.justPressed[button] {
+				return false
+			}
+			if frame.justPressed[button] {
+				edge = true
+			}
+		}
+		held = math.Min(held, duration)
+	}
+	if s.Release {
+		return edge && held >= s.Charge
+	}
+	return edge
+}
+
+// holding returns whether exactly the direction Buttons
+// are held down in the frame out of all directions.
+func (f comboFrame) holding(direction []pixelgl.Button, directions map[pixelgl.Button]bool) bool {
+	want := make(map[pixelgl.Button]bool, len(direction))
+	for _, button := range direction {
+		want[button] = true
+	}
+	for button := range directions {
+		_, held := f.held[button]
+		if down := held && !f.justReleased[button]; down != want[button] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+const comboDt = 0.0625
+
+// comboFrames runs a ComboDetector for a number of frames, pressing and
+// releasing Buttons at the start of the first frame. It returns the
+// Combos detected in the last frame.
+func comboFrames(d *ComboDetector, input *testInput, frames int, press, release []pixelgl.Button) []string {
+	var detected []string
+	for i := 0; i < frames; i++ {
+		input.nextFrame()
+		if i == 0 {
+			for _, b := range press {
+				input.press(b)
+			}
+			for _, b := range release {
+				input.release(b)
+			}
+		}
+		d.Update(comboDt, input)
+		detected = d.DetectedCombos()
+	}
+	return detected
+}
+
+func comboButtons(b ...pixelgl.Button) []pixelgl.Button {
+	return b
+}
+
+func newFireballDetector() *ComboDetector {
+	d := NewComboDetector()
+	d.Add("fireball", Combo{
+		Steps: []ComboStep{
+			Hold(pixelgl.KeyDown),
+			Hold(pixelgl.KeyDown, pixelgl.KeyRight),
+			{Direction: comboButtons(pixelgl.KeyRight), Buttons: comboButtons(pixelgl.KeyX)},
+		},
+		Window: 0.3,
+	})
+	d.Add("fireballLeft", Combo{
+		Steps: []ComboStep{
+			Hold(pixelgl.KeyDown),
+			Hold(pixelgl.KeyDown, pixelgl.KeyLeft),
+			{Direction: comboButtons(pixelgl.KeyLeft), Buttons: comboButtons(pixelgl.KeyX)},
+		},
+		Window: 0.3,
+	})
+	return d
+}
+
+func TestComboDetector_sequence(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+	assert.Nil(t, comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyDown)))
+	assert.Equal(t, []string{"fireball"}, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), nil))
+	assert.True(t, d.Detected("fireball"))
+
+	comboFrames(d, input, 1, nil, nil)
+	assert.False(t, d.Detected("fireball"))
+}
+
+func TestComboDetector_sequenceTooSlow(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 2, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 5, comboButtons(pixelgl.KeyRight), nil)
+
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), comboButtons(pixelgl.KeyDown)))
+}
+
+func TestComboDetector_sequenceOutOfOrder(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyDown), nil)
+
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), comboButtons(pixelgl.KeyDown)))
+}
+
+func TestComboDetector_quarterCircle(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	// down is held from before the motion
+	comboFrames(d, input, 3, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+
+	assert.Equal(t, []string{"fireball"}, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), comboButtons(pixelgl.KeyDown)))
+}
+
+func TestComboDetector_quarterCircleStillDown(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), nil), "down-forward is not forward")
+}
+
+func TestComboDetector_quarterCircleOtherDirection(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyDown, pixelgl.KeyLeft), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), comboButtons(pixelgl.KeyLeft))
+
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), comboButtons(pixelgl.KeyDown)), "down-back is not down")
+}
+
+func TestComboDetector_doubleTap(t *testing.T) {
+	d := NewComboDetector()
+	d.Add("dash", Combo{
+		Steps:  []ComboStep{Press(pixelgl.KeyRight), Press(pixelgl.KeyRight)},
+		Window: 0.25,
+	})
+	input := newTestInput()
+
+	// holding is not tapping
+	assert.Nil(t, comboFrames(d, input, 4, comboButtons(pixelgl.KeyRight), nil))
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyRight))
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyRight))
+	assert.Equal(t, []string{"dash"}, comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil))
+
+	// a third tap does not reuse the second
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyRight))
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil))
+}
+
+func TestComboDetector_chargeRelease(t *testing.T) {
+	d := NewComboDetector()
+	d.Add("flash kick", Combo{
+		Steps: []ComboStep{
+			ChargeRelease(0.5, pixelgl.KeyDown),
+			Press(pixelgl.KeyUp, pixelgl.KeyX),
+		},
+		Window: 0.25,
+	})
+	input := newTestInput()
+
+	// not charged long enough
+	comboFrames(d, input, 4, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyDown))
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyUp, pixelgl.KeyX), nil))
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyUp, pixelgl.KeyX))
+
+	comboFrames(d, input, 20, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, nil, comboButtons(pixelgl.KeyDown))
+	assert.Equal(t, []string{"flash kick"}, comboFrames(d, input, 1, comboButtons(pixelgl.KeyUp, pixelgl.KeyX), nil))
+}
+
+func TestComboDetector_tapWithinFrame(t *testing.T) {
+	d := NewComboDetector()
+	d.Add("tap", Combo{Steps: []ComboStep{Press(pixelgl.KeySpace)}})
+	input := newTestInput()
+
+	input.press(pixelgl.KeySpace)
+	input.release(pixelgl.KeySpace)
+	d.Update(comboDt, input)
+
+	assert.True(t, d.Detected("tap"))
+}
+
+func TestComboDetector_Reset(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyDown), nil)
+	comboFrames(d, input, 1, comboButtons(pixelgl.KeyRight), nil)
+	d.Reset()
+
+	assert.Nil(t, comboFrames(d, input, 1, comboButtons(pixelgl.KeyX), nil))
+	assert.False(t, d.Detected("missing"))
+}
+
+func TestComboDetector_forgetsOldFrames(t *testing.T) {
+	d := newFireballDetector()
+	input := newTestInput()
+
+	comboFrames(d, input, 100, nil, nil)
+
+	assert.Len(t, d.history, 5)
+}
@@ -0,0 +1,133 @@
+package wo
+
+import (
+	"math"
+)
+
+// Easing maps the linear progress of an animation, from 0 to 1, to
+// eased progress. Eased progress starts at 0 and ends at 1, but can
+// overshoot in between, as with the elastic and back easings.
+type Easing func(t float64) float64
+
+const (
+	// backOvershoot is how far back easings overshoot,
+	// about 10% of the distance travelled
+	backOvershoot = 1.70158
+	// elasticPeriod is the period of elastic easings
+	elasticPeriod = 0.3
+)
+
+// EaseLinear does not ease.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad accelerates from zero velocity.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero velocity.
+func EaseOutQuad(t float64) float64 {
+	return EaseOut(EaseInQuad)(t)
+}
+
+// EaseInOutQuad accelerates until halfway, then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	return EaseInOut(EaseInQuad)(t)
+}
+
+// EaseInCubic accelerates from zero velocity, faster than EaseInQuad.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero velocity.
+func EaseOutCubic(t float64) float64 {
+	return EaseOut(EaseInCubic)(t)
+}
+
+// EaseInOutCubic accelerates until halfway, then decelerates.
+func EaseInOutCubic(t float64) float64 {
+	return EaseInOut(EaseInCubic)(t)
+}
+
+// EaseInElastic wobbles with growing amplitude before snapping to the end.
+func EaseInElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return -math.Pow(2, 10*(t-1)) * math.Sin((t-1-elasticPeriod/4)*2*math.Pi/elasticPeriod)
+}
+
+// EaseOutElastic overshoots the end and wobbles until it settles.
+func EaseOutElastic(t float64) float64 {
+	return EaseOut(EaseInElastic)(t)
+}
+
+// EaseInOutElastic wobbles at both the start and the end.
+func EaseInOutElastic(t float64) float64 {
+	return EaseInOut(EaseInElastic)(t)
+}
+
+// EaseInBounce bounces with growing height away from the start.
+func EaseInBounce(t float64) float64 {
+	return EaseOut(EaseOutBounce)(t)
+}
+
+// EaseOutBounce falls to the end and bounces on it, like a dropped ball.
+func EaseOutBounce(t float64) float64 {
+	const n = 7.5625
+	const d = 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// EaseInOutBounce bounces at both the start and the end.
+func EaseInOutBounce(t float64) float64 {
+	return EaseInOut(EaseInBounce)(t)
+}
+
+// EaseInBack pulls back before moving toward the end.
+func EaseInBack(t float64) float64 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+
+// EaseOutBack overshoots the end before settling on it.
+func EaseOutBack(t float64) float64 {
+	return EaseOut(EaseInBack)(t)
+}
+
+// EaseInOutBack pulls back at the start and overshoots the end.
+func EaseInOutBack(t float64) float64 {
+	return EaseInOut(EaseInBack)(t)
+}
+
+// EaseOut reverses an easing in, making it an easing out.
+func EaseOut(in Easing) Easing {
+	return func(t float64) float64 {
+		return 1 - in(1-t)
+	}
+}
+
+// EaseInOut makes an easing in that runs for the first half of
+// the progress and its easing out for the second half.
+func EaseInOut(in Easing) Easing {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return in(2*t) / 2
+		}
+		return 1 - in(2-2*t)/2
+	}
+}
@@ -0,0 +1,46 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasing_endpoints(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear":       EaseLinear,
+		"InQuad":       EaseInQuad,
+		"OutQuad":      EaseOutQuad,
+		"InOutQuad":    EaseInOutQuad,
+		"InCubic":      EaseInCubic,
+		"OutCubic":     EaseOutCubic,
+		"InOutCubic":   EaseInOutCubic,
+		"InElastic":    EaseInElastic,
+		"OutElastic":   EaseOutElastic,
+		"InOutElastic": EaseInOutElastic,
+		"InBounce":     EaseInBounce,
+		"OutBounce":    EaseOutBounce,
+		"InOutBounce":  EaseInOutBounce,
+		"InBack":       EaseInBack,
+		"OutBack":      EaseOutBack,
+		"InOutBack":    EaseInOutBack,
+	}
+	for name, ease := range easings {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, 0, ease(0), 1e-9)
+			assert.InDelta(t, 1, ease(1), 1e-9)
+		})
+	}
+}
+
+func TestEasing_values(t *testing.T) {
+	assert.Equal(t, 0.25, EaseInQuad(0.5))
+	assert.Equal(t, 0.75, EaseOutQuad(0.5))
+	assert.Equal(t, 0.5, EaseInOutQuad(0.5))
+	assert.Equal(t, 0.125, EaseInCubic(0.5))
+	assert.Equal(t, 0.875, EaseOutCubic(0.5))
+	assert.InDelta(t, 0.765625, EaseOutBounce(0.5), 1e-9)
+	assert.True(t, EaseInBack(0.2) < 0, "back easing pulls back")
+	assert.True(t, EaseOutBack(0.8) > 1, "back easing overshoots")
+	assert.True(t, EaseOutElastic(0.2) > 1, "elastic easing overshoots")
+}
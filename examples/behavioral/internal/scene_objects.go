@@ -32,7 +32,7 @@ type scene struct {
 	objects *wobj.Objects
 }
 
-func (w *World) newObjectsScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newObjectsScene(canvas wo.Canvas) (wo.Scene, error) {
 
 	sprite, err := w.loader.Sprite("img/ship_512.png")
 	if err != nil {
@@ -93,7 +93,7 @@ func (s *scene) addObject() {
 	s.objects.Add(o)
 }
 
-func (s *scene) Draw(canvas *pixelgl.Canvas) {
+func (s *scene) Draw(canvas wo.Canvas) {
 	s.objects.Draw(canvas)
 }
 
@@ -37,13 +37,17 @@ type circlesScene struct {
 
 	infoText *text.Text
 
-	keyDelay  float64
+	timers *wo.Scheduler
+	// keysLocked ignores the effect keys until keyDelay
+	// seconds after they last changed an effect
+	keysLocked bool
+
 	cosEffect float64
 	powEffect float64
 	colorized bool
 }
 
-func (w *World) newCirclesScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newCirclesScene(canvas wo.Canvas) (wo.Scene, error) {
 
 	infoFont, err := w.loader.FontFace("fonts/SourceSansPro-Regular.ttf", 16)
 	if err != nil {
@@ -59,20 +63,19 @@ func (w *World) newCirclesScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 		infoText:  infoText,
 		cosEffect: defaultCosEffect,
 		powEffect: defaultPowEffect,
+		timers:    wo.NewScheduler(),
 	}
 	return scene, nil
 }
 
 func (s *circlesScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	s.time += dt
-	s.keyDelay -= dt
-
-	if s.keyDelay < 0 {
+	if !s.keysLocked {
 		s.increaseEffect(input, pixelgl.KeyUp, pixelgl.KeyDown, cosEffectDelta, &s.cosEffect)
 		s.increaseEffect(input, pixelgl.KeyRight, pixelgl.KeyLeft, powEffectDelta, &s.powEffect)
 		if input.JustPressed(pixelgl.KeySpace) {
 			s.colorized = !s.colorized
-			s.keyDelay = keyDelay
+			s.lockKeys()
 		}
 	}
 
@@ -81,16 +84,28 @@ func (s *circlesScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return s.w.maybeSelectScene(input)
 }
 
+func (s *circlesScene) Scheduler() *wo.Scheduler {
+	return s.timers
+}
+
+// lockKeys ignores the effect keys for keyDelay seconds.
+func (s *circlesScene) lockKeys() {
+	s.keysLocked = true
+	s.timers.After(keyDelay, func() {
+		s.keysLocked = false
+	})
+}
+
 func (s *circlesScene) increaseEffect(input wo.Input, keyIncrease, keyDecrease pixelgl.Button, delta float64, value *float64) {
 	if input.Pressed(keyIncrease) {
 		*value += delta
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	} else if input.Pressed(keyDecrease) {
 		*value -= delta
 		if *value < delta {
 			*value = delta
 		}
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	}
 }
 
@@ -123,7 +138,7 @@ func maxRadius(r pixel.Rect) float64 {
 	return math.Sqrt(w*w + h*h)
 }
 
-func (s *circlesScene) Draw(canvas *pixelgl.Canvas) {
+func (s *circlesScene) Draw(canvas wo.Canvas) {
 	s.im.Draw(canvas)
 	drawText(
 		canvas,
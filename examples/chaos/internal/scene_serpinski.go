@@ -10,7 +10,6 @@ import (
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
 	"golang.org/x/image/colornames"
 )
@@ -36,7 +35,7 @@ type sierpinskiScene struct {
 	seed     pixel.Vec
 }
 
-func (w *World) newSierpinskiScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newSierpinskiScene(canvas wo.Canvas) (wo.Scene, error) {
 
 	infoFont, err := w.loader.FontFace("fonts/SourceSansPro-Regular.ttf", 16)
 	if err != nil {
@@ -86,7 +85,7 @@ func (s *sierpinskiScene) addNewPoint() {
 	s.seed = mid
 }
 
-func (s *sierpinskiScene) Draw(canvas *pixelgl.Canvas) {
+func (s *sierpinskiScene) Draw(canvas wo.Canvas) {
 	s.im.Draw(canvas)
 	drawText(
 		canvas,
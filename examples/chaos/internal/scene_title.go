@@ -8,7 +8,6 @@ import (
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
 	"golang.org/x/image/colornames"
 )
@@ -71,7 +70,7 @@ func (t *triangle) update(dt float64) {
 	}
 }
 
-func (t triangle) draw(canvas *pixelgl.Canvas) {
+func (t triangle) draw(canvas wo.Canvas) {
 	t.im.Clear()
 	for i := 1; i < len(t.vertices); i++ {
 		for j := 0; j < i; j++ {
@@ -88,7 +87,7 @@ func (t triangle) draw(canvas *pixelgl.Canvas) {
 	t.im.Draw(canvas)
 }
 
-func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newTitleScene(canvas wo.Canvas) (wo.Scene, error) {
 
 	infoFont, err := w.loader.FontFace("fonts/Lekton-Regular.ttf", 24)
 	if err != nil {
@@ -129,7 +128,7 @@ func (s *titleScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return s.w.maybeSelectScene(input)
 }
 
-func (s *titleScene) Draw(canvas *pixelgl.Canvas) {
+func (s *titleScene) Draw(canvas wo.Canvas) {
 	for _, triangle := range s.triangles {
 		triangle.draw(canvas)
 	}
@@ -30,14 +30,17 @@ type treeScene struct {
 	dirty bool
 	im    *imdraw.IMDraw
 
-	keyDelay float64
+	timers *wo.Scheduler
+	// keysLocked ignores the effect keys until keyDelay
+	// seconds after they last changed an effect
+	keysLocked bool
 
 	depth     int
 	angle     float64
 	colorized bool
 }
 
-func (w *World) newTreeScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newTreeScene(canvas wo.Canvas) (wo.Scene, error) {
 
 	infoFont, err := w.loader.FontFace("fonts/SourceSansPro-Regular.ttf", 16)
 	if err != nil {
@@ -55,19 +58,18 @@ func (w *World) newTreeScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 		depth:    3,
 		angle:    45,
 		dirty:    true,
+		timers:   wo.NewScheduler(),
 	}
 	return scene, nil
 }
 
 func (s *treeScene) Update(dt float64, input wo.Input) wo.SceneResult {
-	s.keyDelay -= dt
-
-	if s.keyDelay <= 0 {
+	if !s.keysLocked {
 		s.increaseEffectInt(input, pixelgl.KeyUp, pixelgl.KeyDown, 1, maxTreeDepth, 1, &s.depth)
 		s.increaseEffectWrapped(input, pixelgl.KeyRight, pixelgl.KeyLeft, -180, 180, 1, &s.angle)
 		if input.JustPressed(pixelgl.KeySpace) {
 			s.colorized = !s.colorized
-			s.keyDelay = keyDelay
+			s.lockKeys()
 			s.dirty = true
 		}
 	}
@@ -78,6 +80,18 @@ func (s *treeScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return s.w.maybeSelectScene(input)
 }
 
+func (s *treeScene) Scheduler() *wo.Scheduler {
+	return s.timers
+}
+
+// lockKeys ignores the effect keys for keyDelay seconds.
+func (s *treeScene) lockKeys() {
+	s.keysLocked = true
+	s.timers.After(keyDelay, func() {
+		s.keysLocked = false
+	})
+}
+
 func (s *treeScene) increaseEffectWrapped(input wo.Input, keyIncrease, keyDecrease pixelgl.Button, min, max, delta float64, value *float64) {
 	if input.Pressed(keyIncrease) {
 		*value += delta
@@ -85,14 +99,14 @@ func (s *treeScene) increaseEffectWrapped(input wo.Input, keyIncrease, keyDecrea
 			*value = min
 		}
 		s.dirty = true
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	} else if input.Pressed(keyDecrease) {
 		*value -= delta
 		if *value < min {
 			*value = max
 		}
 		s.dirty = true
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	}
 }
 
@@ -103,14 +117,14 @@ func (s *treeScene) increaseEffectInt(input wo.Input, keyIncrease, keyDecrease p
 			*value = max
 		}
 		s.dirty = true
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	} else if input.Pressed(keyDecrease) {
 		*value -= delta
 		if *value < min {
 			*value = min
 		}
 		s.dirty = true
-		s.keyDelay = keyDelay
+		s.lockKeys()
 	}
 }
 
@@ -155,7 +169,7 @@ func (s *treeScene) renderTree(im *imdraw.IMDraw, mat pixel.Matrix, depth int, l
 	}
 }
 
-func (s *treeScene) Draw(canvas *pixelgl.Canvas) {
+func (s *treeScene) Draw(canvas wo.Canvas) {
 	s.im.Draw(canvas)
 	drawText(
 		canvas,
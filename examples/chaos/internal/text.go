@@ -1,12 +1,12 @@
 package internal
 
 import (
+	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
 )
 
-func drawText(canvas *pixelgl.Canvas, topLeft pixel.Vec, text *text.Text, lines ...string) {
+func drawText(canvas wo.Canvas, topLeft pixel.Vec, text *text.Text, lines ...string) {
 	const (
 		lineSpacing float64 = 2
 	)
@@ -45,7 +45,7 @@ type World struct {
 func NewWorld(debug bool) *World {
 	return &World{
 		debug:  debug,
-		loader: wo.NewLoaderFromByteReader(res.Load),
+		loader: wo.NewCachingLoaderFromByteReader(res.Load),
 		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -64,37 +64,15 @@ func (w *World) Run() {
 	}
 	world.SetFps(fps)
 
-	currentScene := "title"
+	world.Route(wo.AnyScene, gotoTitle, "title")
+	world.Route(wo.AnyScene, gotoSierpinski, "sierpinski")
+	world.Route(wo.AnyScene, gotoCircles, "circles")
+	world.Route(wo.AnyScene, gotoTree, "tree")
 
-	for {
-		log := logrus.WithField("currentScene", currentScene)
-
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			log.Fatalf("failed to run scene: %v", err)
-		}
-		log = log.WithField("result", result)
-		switch result {
-		case wo.SceneResultError:
-			log.Error("error running scene")
-			return
-		case wo.SceneResultWindowClosed:
-			log.Info("goodbye!")
-			return
-		case gotoTitle:
-			log.Info("Title")
-			currentScene = "title"
-		case gotoSierpinski:
-			log.Info("Sierpinski")
-			currentScene = "sierpinski"
-		case gotoCircles:
-			log.Info("Circles")
-			currentScene = "circles"
-		case gotoTree:
-			log.Info("Tree")
-			currentScene = "tree"
-		}
+	if err := world.Run("title"); err != nil {
+		logrus.Fatalf("error running world: %+v", err)
 	}
+	logrus.Info("goodbye!")
 }
 
 func (w *World) maybeSelectScene(input wo.Input) wo.SceneResult {
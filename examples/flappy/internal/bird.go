@@ -61,7 +61,7 @@ func (b *playBird) updateAlive(dt float64, input wo.Input) {
 	b.speedXY(dx, dy)
 }
 
-func (b *playBird) draw(canvas *pixelgl.Canvas, sprites []*pixel.Sprite) {
+func (b *playBird) draw(canvas wo.Canvas, sprites []*pixel.Sprite) {
 	frameNum := int(b.age*flapRate) % len(sprites)
 	frame := sprites[frameNum]
 	mat := pixel.IM.Moved(b.pos)
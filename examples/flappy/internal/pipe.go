@@ -5,7 +5,6 @@ import (
 
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/sirupsen/logrus"
 )
 
@@ -36,7 +35,7 @@ func (p *playPipe) reset(r *rand.Rand, limit pixel.Rect) {
 	logrus.WithField("pipeY", p.pos.Y).Info("pipe reset")
 }
 
-func (p *playPipe) draw(canvas *pixelgl.Canvas, sprite *pixel.Sprite) {
+func (p *playPipe) draw(canvas wo.Canvas, sprite *pixel.Sprite) {
 	mat := pixel.IM.Moved(p.pos)
 	sprite.Draw(canvas, mat)
 
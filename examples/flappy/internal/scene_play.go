@@ -10,7 +10,6 @@ import (
 	"github.com/explodes/go-wo/examples/flappy/res"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
 	"github.com/golang/freetype/truetype"
 	"github.com/pkg/errors"
@@ -64,7 +63,7 @@ func decodeFontFace(ttf []byte, size float64) (font.Face, error) {
 	return truetype.NewFace(f, &truetype.Options{Size: size}), nil
 }
 
-func (g *FlappyWorld) createPlayScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (g *FlappyWorld) createPlayScene(canvas wo.Canvas) (wo.Scene, error) {
 	fontSrc, err := res.Load("fonts/Flappy.ttf")
 	if err != nil {
 		return nil, errors.Errorf("could not find font: %v", err)
@@ -175,19 +174,19 @@ func (s *playScene) detectPipes() {
 	}
 }
 
-func (s *playScene) Draw(canvas *pixelgl.Canvas) {
+func (s *playScene) Draw(canvas wo.Canvas) {
 	s.drawBackground(canvas)
 	s.drawPipes(canvas)
 	s.drawScore(canvas)
 	s.drawBird(canvas)
 }
 
-func (s *playScene) drawBackground(canvas *pixelgl.Canvas) {
+func (s *playScene) drawBackground(canvas wo.Canvas) {
 	mat := wo.FitAtZero(s.backgroundSprite.Frame(), canvas.Bounds())
 	s.backgroundSprite.Draw(canvas, mat)
 }
 
-func (s *playScene) drawPipes(canvas *pixelgl.Canvas) {
+func (s *playScene) drawPipes(canvas wo.Canvas) {
 	for _, pipe := range s.pipes {
 		pipe.draw(canvas, s.pipeSprite)
 		s.drawDebug(canvas, s.pipeSprite, pipe.pos)
@@ -195,16 +194,16 @@ func (s *playScene) drawPipes(canvas *pixelgl.Canvas) {
 	}
 }
 
-func (s *playScene) drawScore(canvas *pixelgl.Canvas) {
+func (s *playScene) drawScore(canvas wo.Canvas) {
 	s.scoreText.Draw(canvas, pixel.IM.Moved(pixel.V(10, canvas.Bounds().Max.Y-s.scoreText.LineHeight)))
 }
 
-func (s *playScene) drawBird(canvas *pixelgl.Canvas) {
+func (s *playScene) drawBird(canvas wo.Canvas) {
 	s.bird.draw(canvas, s.birdSprites)
 	s.drawDebug(canvas, s.birdSprites[0], s.bird.pos)
 }
 
-func (s *playScene) drawDebug(canvas *pixelgl.Canvas, sprite *pixel.Sprite, pos pixel.Vec) {
+func (s *playScene) drawDebug(canvas wo.Canvas, sprite *pixel.Sprite, pos pixel.Vec) {
 	if !debugPlayScene {
 		return
 	}
@@ -212,7 +211,7 @@ func (s *playScene) drawDebug(canvas *pixelgl.Canvas, sprite *pixel.Sprite, pos
 	s.drawDebugRect(canvas, hitbox)
 }
 
-func (s *playScene) drawDebugRect(canvas *pixelgl.Canvas, r pixel.Rect) {
+func (s *playScene) drawDebugRect(canvas wo.Canvas, r pixel.Rect) {
 	if !debugPlayScene {
 		return
 	}
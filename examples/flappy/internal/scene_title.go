@@ -8,7 +8,6 @@ import (
 	"github.com/explodes/go-wo/examples/flappy/res"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
 	"github.com/golang/freetype/truetype"
 	"github.com/pkg/errors"
@@ -27,7 +26,7 @@ type titleScene struct {
 	log       *logrus.Entry
 }
 
-func (g *FlappyWorld) createTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (g *FlappyWorld) createTitleScene(canvas wo.Canvas) (wo.Scene, error) {
 	fontSrc, err := res.Load("fonts/Flappy.ttf")
 	if err != nil {
 		return nil, errors.Errorf("could not find font: %v", err)
@@ -61,7 +60,7 @@ func (s *titleScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return wo.SceneResultNone
 }
 
-func (s *titleScene) Draw(canvas *pixelgl.Canvas) {
+func (s *titleScene) Draw(canvas wo.Canvas) {
 
 	const pad = 10
 
@@ -85,7 +84,7 @@ func (s *titleScene) Draw(canvas *pixelgl.Canvas) {
 	}
 }
 
-func createDebugIMDraw(canvas *pixelgl.Canvas) *imdraw.IMDraw {
+func createDebugIMDraw(canvas wo.Canvas) *imdraw.IMDraw {
 
 	const div = float64(19)
 
@@ -3,6 +3,7 @@ package internal
 import (
 	"github.com/explodes/go-wo"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/image/colornames"
 )
 
 const (
@@ -11,6 +12,8 @@ const (
 	height = 384
 	fps    = 60
 
+	transitionDuration = 0.6
+
 	noPreviousScore = -1
 )
 
@@ -38,34 +41,17 @@ func (g *FlappyWorld) Run() {
 		"FlappyWorld": g.createPlayScene,
 	}
 
-	world, err := wo.NewWorld(title, width, height, scenes)
+	world, err := wo.NewResizableWorld(title, width, height, wo.FitIntegerScale, scenes)
 	if err != nil {
 		logrus.Fatalf("error starting world: %v", err)
 	}
 	world.SetFps(fps)
 
-	currentScene := "title"
-
-	for {
-		log := logrus.WithField("currentScene", currentScene)
+	world.RouteTransition(wo.AnyScene, SceneResultGoToGame, "FlappyWorld", wo.FadeTransition(colornames.Black, transitionDuration))
+	world.RouteTransition(wo.AnyScene, SceneResultGoToTitle, "title", wo.CrossfadeTransition(transitionDuration))
 
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			log.Fatalf("failed to run scene: %v", err)
-		}
-		switch result {
-		case wo.SceneResultError:
-			log.Error("error running scene")
-			return
-		case wo.SceneResultWindowClosed:
-			log.Info("goodbye!")
-			return
-		case SceneResultGoToGame:
-			log.Info("let the games begin...")
-			currentScene = "FlappyWorld"
-		case SceneResultGoToTitle:
-			log.Info("FlappyWorld over")
-			currentScene = "title"
-		}
+	if err := world.Run("title"); err != nil {
+		logrus.Fatalf("error running world: %+v", err)
 	}
+	logrus.Info("goodbye!")
 }
@@ -1,9 +1,9 @@
 package internal
 
 import (
+	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 )
 
 type IMDrawable struct {
@@ -12,7 +12,7 @@ type IMDrawable struct {
 }
 
 func (i *IMDrawable) Draw(target pixel.Target, mat pixel.Matrix) {
-	c := target.(*pixelgl.Canvas)
+	c := target.(wo.Canvas)
 	mat = mat.Moved(pixel.ZV.Sub(i.bounds.Center()))
 	c.SetMatrix(mat)
 	i.im.Draw(c)
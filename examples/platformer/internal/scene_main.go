@@ -39,7 +39,7 @@ type mainScene struct {
 	layers wobj.Layers
 }
 
-func (w *World) createMainScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) createMainScene(canvas wo.Canvas) (wo.Scene, error) {
 	if width != height {
 		panic("window is not square!")
 	}
@@ -64,7 +64,7 @@ func (s *mainScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return wo.SceneResultNone
 }
 
-func (s *mainScene) Draw(canvas *pixelgl.Canvas) {
+func (s *mainScene) Draw(canvas wo.Canvas) {
 	s.layers.Draw(canvas)
 }
 
@@ -118,19 +118,12 @@ func (s *mainScene) createPlayer(x, y, size float64) *wobj.Object {
 				}
 
 				// can jump off of the ground
-				if ground && input.Pressed(pixelgl.KeyW, pixelgl.KeyUp) {
+				if ground && s.w.bindings.Pressed(input, actionJump) {
 					player.Velocity.Y = jumpSpeed
 				}
 
 				// can move but not into walls
-				switch {
-				case input.Pressed(pixelgl.KeyA, pixelgl.KeyLeft):
-					player.Velocity.X = -runSpeed
-				case input.Pressed(pixelgl.KeyD, pixelgl.KeyRight):
-					player.Velocity.X = +runSpeed
-				default:
-					player.Velocity.X = 0
-				}
+				player.Velocity.X = s.w.bindings.Value(input, axisRun) * runSpeed
 
 			},
 		),
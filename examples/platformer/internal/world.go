@@ -5,10 +5,9 @@ import (
 
 	"time"
 
-	"errors"
-
 	"github.com/explodes/go-wo"
 	"github.com/explodes/go-wo/examples/platformer/res"
+	"github.com/faiface/pixel/pixelgl"
 )
 
 const (
@@ -16,6 +15,12 @@ const (
 	width  = 600
 	height = width
 	fps    = 60
+
+	physicsStep     = 1.0 / 120
+	maxPhysicsSteps = 8
+
+	actionJump = "jump"
+	axisRun    = "run"
 )
 
 type World struct {
@@ -23,6 +28,8 @@ type World struct {
 	debug  bool
 	rng    *rand.Rand
 	input  wo.Input
+
+	bindings *wo.Bindings
 }
 
 func NewWorld(debug bool) *World {
@@ -30,9 +37,26 @@ func NewWorld(debug bool) *World {
 		loader: wo.NewLoaderFromByteReader(res.Load),
 		debug:  debug,
 		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+
+		bindings: defaultBindings(),
 	}
 }
 
+// defaultBindings are the controls of the player.
+func defaultBindings() *wo.Bindings {
+	bindings := wo.NewBindings()
+	bindings.BindAction(actionJump, pixelgl.KeyW, pixelgl.KeyUp)
+	bindings.BindGamepadAction(actionJump, wo.GamepadButton{Joystick: wo.AnyJoystick, Button: 0})
+	bindings.BindAxis(axisRun, wo.AxisBinding{
+		Negative: []pixelgl.Button{pixelgl.KeyA, pixelgl.KeyLeft},
+		Positive: []pixelgl.Button{pixelgl.KeyD, pixelgl.KeyRight},
+		Gamepad:  []wo.GamepadAxis{{Joystick: wo.AnyJoystick, Axis: 0}},
+		// run left when both directions are held
+		NegativeWins: true,
+	})
+	return bindings
+}
+
 func (w *World) Run() error {
 	scenes := map[string]wo.SceneFactory{
 		"main": w.createMainScene,
@@ -42,19 +66,8 @@ func (w *World) Run() error {
 		return err
 	}
 	world.SetFps(fps)
+	world.SetFixedStep(physicsStep, maxPhysicsSteps)
 	w.input = world.Input()
 
-	currentScene := "main"
-	for {
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			return err
-		}
-		switch result {
-		case wo.SceneResultError:
-			return errors.New("error running scene")
-		case wo.SceneResultWindowClosed:
-			return nil
-		}
-	}
+	return world.Run("main")
 }
@@ -3,7 +3,6 @@ package internal
 import (
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/pixelgl"
 )
 
 const (
@@ -37,7 +36,7 @@ func (b *ball) hit(direction pixel.Vec) {
 	b.vel = b.vel.Add(direction.Scaled(ballHitStrength))
 }
 
-func (b *ball) draw(canvas *pixelgl.Canvas) {
+func (b *ball) draw(canvas wo.Canvas) {
 	mat := pixel.IM.Moved(b.pos)
 	b.sprite.Draw(canvas, mat)
 }
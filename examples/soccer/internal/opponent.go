@@ -3,7 +3,6 @@ package internal
 import (
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
-	"github.com/faiface/pixel/pixelgl"
 )
 
 const (
@@ -32,7 +31,7 @@ func (o *opponent) update(dt float64, ball *ball) {
 	o.pos = o.pos.Sub(dv.Scaled(opponentSpeed * dt))
 }
 
-func (o *opponent) draw(canvas *pixelgl.Canvas) {
+func (o *opponent) draw(canvas wo.Canvas) {
 	mat := pixel.IM.Moved(o.pos).Rotated(o.pos, o.rot+wo.DegToRad(90))
 	o.sprite.Draw(canvas, mat)
 }
@@ -45,7 +45,7 @@ func (p *player) update(dt float64, ball *ball, input wo.Input) {
 	p.pos = p.pos.Add(pixel.V(dx, dy))
 }
 
-func (p *player) draw(canvas *pixelgl.Canvas) {
+func (p *player) draw(canvas wo.Canvas) {
 	mat := pixel.IM.Moved(p.pos).Rotated(p.pos, p.rot+wo.DegToRad(90))
 	p.sprite.Draw(canvas, mat)
 }
@@ -4,7 +4,6 @@ import (
 	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/imdraw"
-	"github.com/faiface/pixel/pixelgl"
 	"golang.org/x/image/colornames"
 )
 
@@ -35,7 +34,7 @@ type fieldScene struct {
 	debug bool
 }
 
-func (w *World) createFieldScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) createFieldScene(canvas wo.Canvas) (wo.Scene, error) {
 	backgroundSprite, err := w.loader.Sprite("img/field.png", wo.ResizeTransformer(width, height))
 	if err != nil {
 		return nil, err
@@ -158,7 +157,7 @@ func reflectHori(vel pixel.Vec) pixel.Vec {
 	return pixel.V(vel.X, -vel.Y)
 }
 
-func (s *fieldScene) Draw(canvas *pixelgl.Canvas) {
+func (s *fieldScene) Draw(canvas wo.Canvas) {
 	s.backgroundSprite.Draw(canvas, pixel.IM.Moved(canvas.Bounds().Center()))
 	s.ball.draw(canvas)
 	s.player.draw(canvas)
@@ -174,7 +173,7 @@ func (r rectHitBox) hitBox() pixel.Rect {
 	return pixel.Rect(r)
 }
 
-func drawHitBoxes(canvas *pixelgl.Canvas, hitBoxers ...hitBoxer) {
+func drawHitBoxes(canvas wo.Canvas, hitBoxers ...hitBoxer) {
 	im := imdraw.New(nil)
 	im.Color = colornames.Red
 	for _, h := range hitBoxers {
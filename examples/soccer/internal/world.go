@@ -36,22 +36,8 @@ func (w *World) Run() {
 	}
 	world.SetFps(fps)
 
-	currentScene := "field"
-
-	for {
-		log := logrus.WithField("currentScene", currentScene)
-
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			log.Fatalf("failed to run scene: %v", err)
-		}
-		switch result {
-		case wo.SceneResultError:
-			log.Error("error running scene")
-			return
-		case wo.SceneResultWindowClosed:
-			log.Info("goodbye!")
-			return
-		}
+	if err := world.Run("field"); err != nil {
+		logrus.Fatalf("error running world: %+v", err)
 	}
+	logrus.Info("goodbye!")
 }
@@ -83,46 +83,56 @@ type gameScene struct {
 
 	message *text.Text
 
+	assets *wo.LoaderScope
 	cannon *wo.Sound
 
 	bluePlayer *wobj.Object
 	redPlayer  *wobj.Object
 
-	victoryTime float64
+	timers *wo.Scheduler
+	result wo.SceneResult
 
 	shot wobj.Drawable
 
-	blueShotDelay float64
-	redShotDelay  float64
+	// blueShots and redShots fire the cannons of tanks that drive
+	// straight, and are cancelled while the tanks rotate
+	blueShots *wo.Timer
+	redShots  *wo.Timer
 
 	layers wobj.Layers
 }
 
-func (w *World) newGameScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newGameScene(canvas wo.Canvas) (scene wo.Scene, err error) {
+	assets := w.loader.Scope()
+	defer func() {
+		if err != nil {
+			assets.Release()
+		}
+	}()
 
-	countdownFont, err := w.loader.FontFace("fonts/DampfPlatzs.ttf", 42)
+	countdownFont, err := assets.FontFace("fonts/DampfPlatzs.ttf", 42)
 	if err != nil {
 		return nil, err
 	}
 	defer countdownFont.Close()
 	countdownText := text.New(pixel.V(canvas.Bounds().W()/2, 10), text.NewAtlas(countdownFont, text.ASCII))
 
-	cannon, err := w.loader.Sound("wav", "sound/tank.wav")
+	cannon, err := assets.Sound("wav", "sound/tank.wav")
 	if err != nil {
 		return nil, err
 	}
 
-	shotSprite, err := w.loader.Sprite("img/shot.png")
+	shotSprite, err := assets.Sprite("img/shot.png")
 	if err != nil {
 		return nil, err
 	}
 
-	dirtSprite, err := w.loader.Sprite("img/dirt.jpg")
+	dirtSprite, err := assets.Sprite("img/dirt.jpg")
 	if err != nil {
 		return nil, err
 	}
 
-	tankSheet, err := w.loader.SpriteSheet("img/tanks.png", wo.SpriteSheetOptions{
+	tankSheet, err := assets.SpriteSheet("img/tanks.png", wo.SpriteSheetOptions{
 		Width:   149,
 		Height:  166,
 		Columns: 1,
@@ -133,7 +143,7 @@ func (w *World) newGameScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 	}
 	blueTankDrawable := wobj.NewSpriteSheetDrawable(tankSheet)
 
-	tankSheet, err = w.loader.SpriteSheet("img/tanks.png", wo.SpriteSheetOptions{
+	tankSheet, err = assets.SpriteSheet("img/tanks.png", wo.SpriteSheetOptions{
 		Width:   149,
 		Height:  166,
 		Columns: 1,
@@ -154,8 +164,11 @@ func (w *World) newGameScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 		layers:  wobj.NewLayers(numLayers),
 		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
 		shot:    wobj.NewSpriteDrawable(shotSprite),
+		assets:  assets,
 		cannon:  cannon,
 		message: countdownText,
+		timers:  wo.NewScheduler(),
+		result:  wo.SceneResultNone,
 	}
 
 	rot1 := wo.DegToRad(135)
@@ -263,22 +276,17 @@ func (s *gameScene) Update(dt float64, input wo.Input) wo.SceneResult {
 		s.message.Color = countdownColors[countdownColorIndex]
 		s.message.WriteString(strconv.Itoa(seconds))
 	case phaseBattle:
-		s.blueShotDelay += dt
-		s.redShotDelay += dt
 		s.layers.Update(dt)
-	case phaseBlueVictory:
-		fallthrough
-	case phaseRedVictory:
-		s.victoryTime -= dt
-		if s.victoryTime <= 0 {
-			return gotoTitle
-		}
 	}
 
-	return wo.SceneResultNone
+	return s.result
 }
 
-func (s *gameScene) Draw(canvas *pixelgl.Canvas) {
+func (s *gameScene) Scheduler() *wo.Scheduler {
+	return s.timers
+}
+
+func (s *gameScene) Draw(canvas wo.Canvas) {
 	s.layers.Draw(canvas)
 
 	switch s.phase {
@@ -296,14 +304,11 @@ func (s *gameScene) behaviorBlueRotateOnButton(source *wobj.Object, dt float64)
 	if s.input.Pressed(pixelgl.KeyA) {
 		// rotate
 		source.Rot += wo.DegToRad(-tankRotatesPerSecond*360) * dt
-		s.blueShotDelay = 0
+		s.blueShots = cancelShots(s.blueShots)
 	} else {
 		source.Velocity = pixel.V(tankSpeed, 0).Rotated(source.Rot)
 		wobj.Movement(source, dt)
-		if s.blueShotDelay > 1.0/autoShotPerSecond {
-			s.spawnBlueShots()
-			s.blueShotDelay = 0
-		}
+		s.blueShots = s.autoShots(s.blueShots, s.spawnBlueShots)
 	}
 }
 
@@ -311,17 +316,31 @@ func (s *gameScene) behaviorRedRotateOnButton(source *wobj.Object, dt float64) {
 	if s.input.Pressed(pixelgl.KeyL) {
 		// rotate
 		source.Rot += wo.DegToRad(-tankRotatesPerSecond*360) * dt
-		s.redShotDelay = 0
+		s.redShots = cancelShots(s.redShots)
 	} else {
 		source.Velocity = pixel.V(tankSpeed, 0).Rotated(source.Rot)
 		wobj.Movement(source, dt)
-		if s.redShotDelay > 1.0/autoShotPerSecond {
-			s.spawnRedShots()
-			s.redShotDelay = 0
-		}
+		s.redShots = s.autoShots(s.redShots, s.spawnRedShots)
 	}
 }
 
+// autoShots returns the timer that fires a tank's cannon every time it
+// has driven straight for a while, scheduling it if it is not running.
+func (s *gameScene) autoShots(shots *wo.Timer, spawn func()) *wo.Timer {
+	if shots != nil && shots.Active() {
+		return shots
+	}
+	return s.timers.Every(1.0/autoShotPerSecond, spawn)
+}
+
+// cancelShots stops a tank's cannon from firing.
+func cancelShots(shots *wo.Timer) *wo.Timer {
+	if shots != nil {
+		shots.Cancel()
+	}
+	return nil
+}
+
 func (s *gameScene) spawnBlueShots() {
 
 	bounds := s.bluePlayer.Bounds()
@@ -425,7 +444,11 @@ func (s *gameScene) behaviorBlueHitsRedBullet(source *wobj.Object, dt float64) {
 }
 
 func (s *gameScene) onVictory(winner string, textColor color.Color) {
-	s.victoryTime = victoryMessageDuration
+	s.blueShots = cancelShots(s.blueShots)
+	s.redShots = cancelShots(s.redShots)
+	s.timers.After(victoryMessageDuration, func() {
+		s.result = gotoTitle
+	})
 	s.message.Clear()
 	s.message.Color = textColor
 
@@ -434,3 +457,10 @@ func (s *gameScene) onVictory(winner string, textColor color.Color) {
 
 	s.message.WriteString(victoryMessage)
 }
+
+// Dispose releases the assets of the scene. World.Run disposes it
+// after creating the next scene, so fonts the title scene shares
+// are not loaded again.
+func (s *gameScene) Dispose() {
+	s.assets.Release()
+}
@@ -30,7 +30,8 @@ const (
 )
 
 type titleScene struct {
-	rng *rand.Rand
+	rng    *rand.Rand
+	assets *wo.LoaderScope
 
 	title      *text.Text
 	titlePos   []pixel.Vec
@@ -41,14 +42,22 @@ type titleScene struct {
 	instructions *text.Text
 }
 
-func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) newTitleScene(canvas wo.Canvas) (scene wo.Scene, err error) {
+	// the soundtrack keeps looping after the title, so the world holds it
 	soundtrack, err := w.loader.Sound("mp3", "music/octane.mp3")
 	if err != nil {
 		return nil, err
 	}
 	go loopSoundtrack(w.speaker.Audible(soundtrack))
 
-	instructionsFont, err := w.loader.FontFace("fonts/Lekton-Regular.ttf", 12)
+	assets := w.loader.Scope()
+	defer func() {
+		if err != nil {
+			assets.Release()
+		}
+	}()
+
+	instructionsFont, err := assets.FontFace("fonts/Lekton-Regular.ttf", 12)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +71,7 @@ func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 		}
 	}
 
-	helpFont, err := w.loader.FontFace("fonts/DampfPlatzs.ttf", 24)
+	helpFont, err := assets.FontFace("fonts/DampfPlatzs.ttf", 24)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +80,7 @@ func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 	helpText.Color = colornames.White
 	helpText.WriteString("press space to battle")
 
-	titleFont, err := w.loader.FontFace("fonts/DampfPlatz.ttf", 240)
+	titleFont, err := assets.FontFace("fonts/DampfPlatz.ttf", 240)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +89,7 @@ func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 	titleText.Color = colornames.White
 	titleText.WriteString("Tanks")
 
-	scoreFont, err := w.loader.FontFace("fonts/BlackKnightFLF.ttf", 36)
+	scoreFont, err := assets.FontFace("fonts/BlackKnightFLF.ttf", 36)
 	if err != nil {
 		return nil, err
 	}
@@ -95,8 +104,9 @@ func (w *World) newTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
 		scoreText.WriteString(fmt.Sprintf("Red: %d", w.redScore))
 	}
 
-	scene := &titleScene{
+	scene = &titleScene{
 		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		assets:   assets,
 		title:    titleText,
 		titlePos: make([]pixel.Vec, 5),
 		titleColor: []color.Color{
@@ -127,7 +137,7 @@ func (s *titleScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return wo.SceneResultNone
 }
 
-func (s *titleScene) Draw(canvas *pixelgl.Canvas) {
+func (s *titleScene) Draw(canvas wo.Canvas) {
 	for i := 0; i < len(s.titlePos); i++ {
 		textColor := s.titleColor[i]
 		offset := s.titlePos[i]
@@ -145,3 +155,10 @@ func loopSoundtrack(audible *wo.Audible) {
 		<-time.After(soundtrackDuration)
 	}
 }
+
+// Dispose releases the assets of the scene. The game scene is
+// created before this is called, so the assets it shares with
+// the title, such as fonts, stay loaded.
+func (s *titleScene) Dispose() {
+	s.assets.Release()
+}
@@ -1,9 +1,13 @@
 package internal
 
 import (
+	"time"
+
 	"github.com/explodes/go-wo"
 	"github.com/explodes/go-wo/examples/tanks/res"
+	"github.com/faiface/pixel"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/image/colornames"
 )
 
 const (
@@ -12,24 +16,38 @@ const (
 	height = 1080 / 5 * 2
 	fps    = 60
 
+	physicsStep     = 1.0 / 120
+	maxPhysicsSteps = 8
+
+	transitionDuration = 0.75
+
+	assetReloadInterval = 500 * time.Millisecond
+
 	gotoBattle wo.SceneResult = 1
 	gotoTitle  wo.SceneResult = 2
 )
 
 type World struct {
-	loader  wo.Loader
+	loader  *wo.CachingLoader
 	debug   bool
+	dev     bool
 	speaker *wo.Speaker
 
 	blueScore int
 	redScore  int
 }
 
-func NewWorld(debug bool) *World {
-
+// NewWorld creates the game. Assets are loaded from a directory and
+// reloaded when they change if assetDir is set, or are embedded otherwise.
+func NewWorld(debug bool, assetDir string) *World {
+	loader := wo.NewCachingLoaderFromByteReader(res.Load)
+	if assetDir != "" {
+		loader = wo.NewDevLoader(assetDir)
+	}
 	return &World{
-		loader: wo.NewLoaderFromByteReader(res.Load),
+		loader: loader,
 		debug:  debug,
+		dev:    assetDir != "",
 	}
 }
 
@@ -44,31 +62,21 @@ func (w *World) Run() {
 	}
 	w.speaker = speaker
 
-	world, err := wo.NewWorld(title, width, height, scenes)
+	world, err := wo.NewResizableWorld(title, width, height, wo.FitIntegerScale, scenes)
 	if err != nil {
 		logrus.Fatalf("error starting world: %v", err)
 	}
 	world.SetFps(fps)
+	world.SetFixedStep(physicsStep, maxPhysicsSteps)
+	if w.dev {
+		world.SetAssetReloader(w.loader, assetReloadInterval)
+	}
 
-	currentScene := "title"
+	world.RouteTransition(wo.AnyScene, gotoTitle, "title", wo.FadeTransition(colornames.Black, transitionDuration))
+	world.RouteTransition(wo.AnyScene, gotoBattle, "game", wo.SlideTransition(pixel.V(-1, 0), transitionDuration))
 
-	for {
-		log := logrus.WithField("currentScene", currentScene)
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			log.Fatalf("failed to run scene: %v", err)
-		}
-		switch result {
-		case gotoTitle:
-			currentScene = "title"
-		case gotoBattle:
-			currentScene = "game"
-		case wo.SceneResultError:
-			log.Error("error running scene")
-			return
-		case wo.SceneResultWindowClosed:
-			log.Info("goodbye!")
-			return
-		}
+	if err := world.Run("title"); err != nil {
+		logrus.Fatalf("error running world: %+v", err)
 	}
+	logrus.Info("goodbye!")
 }
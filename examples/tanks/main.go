@@ -8,11 +8,12 @@ import (
 )
 
 var (
-	debug = flag.Bool("debug", false, "enable debug drawing")
+	debug  = flag.Bool("debug", false, "enable debug drawing")
+	assets = flag.String("assets", "", "load assets from this directory and reload them when they change")
 )
 
 func main() {
 	flag.Parse()
-	g := internal.NewWorld(*debug)
+	g := internal.NewWorld(*debug, *assets)
 	wo.Run(g.Run)
 }
@@ -0,0 +1,67 @@
+package wo
+
+import (
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// FitMode decides how a World's canvas is scaled to fill its window.
+type FitMode int
+
+const (
+	// FitStretch scales the canvas to fill the whole window,
+	// distorting it if the aspect ratios differ.
+	FitStretch FitMode = iota
+
+	// FitLetterbox scales the canvas as large as it fits in the window
+	// while keeping its aspect ratio. The canvas is centered and the rest
+	// of the window is filled with bars.
+	FitLetterbox
+
+	// FitIntegerScale scales the canvas by the largest whole number that
+	// fits in the window, so that every canvas pixel covers the same number
+	// of window pixels. The canvas is centered on whole window pixels.
+	// Windows smaller than the canvas fall back to FitLetterbox.
+	FitIntegerScale
+)
+
+// String returns the name of the FitMode.
+func (f FitMode) String() string {
+	switch f {
+	case FitStretch:
+		return "stretch"
+	case FitLetterbox:
+		return "letterbox"
+	case FitIntegerScale:
+		return "integer scale"
+	default:
+		return "unknown"
+	}
+}
+
+// FitMatrix returns the Matrix that draws a canvas, which is drawn centered
+// at the origin, onto the window rectangle using the given FitMode.
+func FitMatrix(mode FitMode, canvas, window pixel.Rect) pixel.Matrix {
+	if canvas.W() <= 0 || canvas.H() <= 0 {
+		return pixel.IM.Moved(window.Center())
+	}
+	xscale := window.W() / canvas.W()
+	yscale := window.H() / canvas.H()
+
+	switch mode {
+	case FitLetterbox:
+		scale := math.Min(xscale, yscale)
+		return pixel.IM.Scaled(pixel.ZV, scale).Moved(window.Center())
+	case FitIntegerScale:
+		scale := math.Floor(math.Min(xscale, yscale))
+		if scale < 1 {
+			return FitMatrix(FitLetterbox, canvas, window)
+		}
+		half := canvas.Size().Scaled(scale / 2)
+		min := window.Center().Sub(half).Map(math.Floor)
+		return pixel.IM.Scaled(pixel.ZV, scale).Moved(min.Add(half))
+	default:
+		return pixel.IM.ScaledXY(pixel.ZV, pixel.V(xscale, yscale)).Moved(window.Center())
+	}
+}
@@ -0,0 +1,52 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitMatrix(t *testing.T) {
+	canvas := pixel.R(0, 0, 100, 50)
+	cases := []struct {
+		name   string
+		mode   FitMode
+		window pixel.Rect
+		// min and max are where the corners of the canvas end up
+		min, max pixel.Vec
+	}{
+		{"stretch same size", FitStretch, pixel.R(0, 0, 100, 50), pixel.V(0, 0), pixel.V(100, 50)},
+		{"stretch", FitStretch, pixel.R(0, 0, 300, 300), pixel.V(0, 0), pixel.V(300, 300)},
+		{"letterbox wide", FitLetterbox, pixel.R(0, 0, 400, 100), pixel.V(100, 0), pixel.V(300, 100)},
+		{"letterbox tall", FitLetterbox, pixel.R(0, 0, 200, 400), pixel.V(0, 150), pixel.V(200, 250)},
+		{"letterbox shrink", FitLetterbox, pixel.R(0, 0, 50, 50), pixel.V(0, 12.5), pixel.V(50, 37.5)},
+		{"integer", FitIntegerScale, pixel.R(0, 0, 350, 120), pixel.V(75, 10), pixel.V(275, 110)},
+		{"integer whole pixels", FitIntegerScale, pixel.R(0, 0, 301, 151), pixel.V(0, 0), pixel.V(300, 150)},
+		{"integer too small", FitIntegerScale, pixel.R(0, 0, 50, 50), pixel.V(0, 12.5), pixel.V(50, 37.5)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mat := FitMatrix(c.mode, canvas, c.window)
+
+			// canvases are drawn centered at the origin
+			centered := canvas.Moved(NegV(canvas.Center()))
+			assert.Equal(t, c.min, mat.Project(centered.Min))
+			assert.Equal(t, c.max, mat.Project(centered.Max))
+		})
+	}
+}
+
+func TestFitMatrix_emptyCanvas(t *testing.T) {
+	mat := FitMatrix(FitLetterbox, pixel.R(0, 0, 0, 0), pixel.R(0, 0, 10, 10))
+
+	assert.Equal(t, pixel.V(5, 5), mat.Project(pixel.ZV))
+}
+
+func TestFitMode_String(t *testing.T) {
+	assert.Equal(t, "stretch", FitStretch.String())
+	assert.Equal(t, "letterbox", FitLetterbox.String())
+	assert.Equal(t, "integer scale", FitIntegerScale.String())
+	assert.Equal(t, "unknown", FitMode(-1).String())
+}
@@ -1,13 +1,63 @@
 package wo
 
 import (
+	"runtime"
 	"time"
 )
 
+const (
+	defaultSpinThreshold = 2 * time.Millisecond
+	defaultDeltaWindow   = 10
+
+	// maxStillSpins is the number of times the clock can tell the same
+	// time while spinning before the rest of the wait is slept instead
+	maxStillSpins = 100
+)
+
+// FrameWait is the way an FpsLimiter waits for the next frame.
+type FrameWait int
+
+const (
+	// FrameWaitSleep sleeps until the next frame. Sleeping regularly
+	// overshoots by a millisecond or more on some systems.
+	FrameWaitSleep FrameWait = iota
+	// FrameWaitHybrid sleeps until shortly before the next frame and then
+	// spins until it is due. How much sleeps overshoot is tracked to wake
+	// up in time, and a frame that still ends late shortens the next.
+	FrameWaitHybrid
+)
+
+// DeltaMode is what an FpsLimiter does with time deltas
+// longer than the Max of its DeltaPolicy.
+type DeltaMode int
+
+const (
+	// DeltaRaw returns time deltas as they were measured.
+	DeltaRaw DeltaMode = iota
+	// DeltaClamp shortens long time deltas to Max.
+	DeltaClamp
+	// DeltaSmooth replaces long time deltas with the average
+	// of the recent time deltas that were not too long.
+	DeltaSmooth
+)
+
+// DeltaPolicy limits the time deltas returned by an FpsLimiter, such as
+// after a breakpoint or while the window is dragged, when a frame can
+// take seconds.
+type DeltaPolicy struct {
+	// Mode is what is done with time deltas longer than Max.
+	Mode DeltaMode
+	// Max is the longest time delta that is returned as measured.
+	Max time.Duration
+	// Window is the number of recent time deltas averaged by
+	// DeltaSmooth. It is 10 if it is 0 or less.
+	Window int
+}
+
 // FpsLimiter is a tool used to limit the number of frames
 // drawn or executed to a given fps (frames per second).
 type FpsLimiter struct {
-	clock clock
+	clock Clock
 
 	// wait is duration to wait each frame to keep a
 	// consistent FPS
@@ -16,17 +66,29 @@ type FpsLimiter struct {
 	// frameStart marks the time a frame was started
 	// with StartFrame()
 	frameStart time.Time
+
+	frameWait     FrameWait
+	spinThreshold time.Duration
+	// drift is how long after it was due the last frame ended
+	drift time.Duration
+	// oversleep is the estimate of how much sleeps overshoot
+	oversleep time.Duration
+
+	deltaPolicy DeltaPolicy
+	// recent are the most recent time deltas within the DeltaPolicy's Max
+	recent []time.Duration
 }
 
 // NewFpsLimiter creates a new FpsLimiter.
 func NewFpsLimiter(maxFps float64) *FpsLimiter {
-	return newFpsLimiterClock(maxFps, &systemClock{})
+	return NewFpsLimiterClock(maxFps, NewSystemClock())
 }
 
-// NewFpsLimiter creates a new FpsLimiter with the given clock
-func newFpsLimiterClock(maxFps float64, clock clock) *FpsLimiter {
+// NewFpsLimiterClock creates a new FpsLimiter with the given Clock.
+func NewFpsLimiterClock(maxFps float64, clock Clock) *FpsLimiter {
 	fpsLimiter := &FpsLimiter{
-		clock: clock,
+		clock:         clock,
+		spinThreshold: defaultSpinThreshold,
 	}
 	fpsLimiter.Reset()
 	fpsLimiter.SetLimit(maxFps)
@@ -39,18 +101,167 @@ func (f *FpsLimiter) Reset() {
 	f.frameStart = f.clock.Now()
 }
 
+// SetClock replaces the Clock frames are timed with and
+// restarts the current frame.
+func (f *FpsLimiter) SetClock(clock Clock) {
+	f.clock = clock
+	f.Reset()
+}
+
+// Clock returns the Clock frames are timed with.
+func (f *FpsLimiter) Clock() Clock {
+	return f.clock
+}
+
+// SetFrameWait sets the way the FpsLimiter waits for the next frame.
+func (f *FpsLimiter) SetFrameWait(frameWait FrameWait) {
+	f.frameWait = frameWait
+	f.drift = 0
+}
+
+// FrameWait returns the way the FpsLimiter waits for the next frame.
+func (f *FpsLimiter) FrameWait() FrameWait {
+	return f.frameWait
+}
+
+// SetSpinThreshold sets how long before the next frame FrameWaitHybrid
+// stops sleeping and starts spinning, in addition to how much its sleeps
+// have been overshooting. Longer thresholds use more CPU time.
+func (f *FpsLimiter) SetSpinThreshold(threshold time.Duration) {
+	f.spinThreshold = threshold
+}
+
+// Drift returns how long after it was due the last wait for
+// a frame ended, which is negative if it ended early.
+func (f *FpsLimiter) Drift() time.Duration {
+	return f.drift
+}
+
+// SetDeltaPolicy sets the DeltaPolicy that limits the time
+// deltas returned by StartFrame.
+func (f *FpsLimiter) SetDeltaPolicy(policy DeltaPolicy) {
+	if policy.Window <= 0 {
+		policy.Window = defaultDeltaWindow
+	}
+	f.deltaPolicy = policy
+	f.recent = nil
+}
+
+// DeltaPolicy returns the DeltaPolicy that limits the
+// time deltas returned by StartFrame.
+func (f *FpsLimiter) DeltaPolicy() DeltaPolicy {
+	return f.deltaPolicy
+}
+
 // StartFrame marks the beginning of a frame and returns the time
-// since the last frame.
+// since the last frame, limited by the DeltaPolicy.
 func (f *FpsLimiter) StartFrame() float64 {
-	delta := f.clock.Since(f.frameStart).Seconds()
+	return f.startFrame().Seconds()
+}
+
+// startFrame marks the beginning of a frame and returns the exact
+// duration since the last frame, limited by the DeltaPolicy.
+func (f *FpsLimiter) startFrame() time.Duration {
+	return f.limitDelta(f.measureFrame())
+}
+
+// measureFrame marks the beginning of a frame and returns the
+// exact duration since the last frame as it was measured.
+func (f *FpsLimiter) measureFrame() time.Duration {
+	delta := f.clock.Since(f.frameStart)
 	f.Reset()
 	return delta
 }
 
+// limitDelta applies the DeltaPolicy to a time delta.
+func (f *FpsLimiter) limitDelta(delta time.Duration) time.Duration {
+	policy := f.deltaPolicy
+	if policy.Mode == DeltaRaw || policy.Max <= 0 {
+		return delta
+	}
+	if delta <= policy.Max {
+		f.recent = append(f.recent, delta)
+		if len(f.recent) > policy.Window {
+			f.recent = f.recent[len(f.recent)-policy.Window:]
+		}
+		return delta
+	}
+	if policy.Mode == DeltaSmooth && len(f.recent) > 0 {
+		var total time.Duration
+		for _, recent := range f.recent {
+			total += recent
+		}
+		return total / time.Duration(len(f.recent))
+	}
+	return policy.Max
+}
+
 // WaitForNextFrame sleeps the amount of time required to limit
 // to the specified fps.
 func (f *FpsLimiter) WaitForNextFrame() {
+	if f.frameWait == FrameWaitHybrid {
+		f.waitHybrid()
+		return
+	}
+	deadline := f.frameStart.Add(f.wait)
 	f.clock.Sleep(f.wait - f.clock.Since(f.frameStart))
+	f.drift = f.clock.Since(deadline)
+}
+
+// waitHybrid sleeps until shortly before the next frame is due and
+// spins for the rest of the wait. The frame is due earlier if the
+// last one ended late, so that frames keep an even pace on average.
+func (f *FpsLimiter) waitHybrid() {
+	compensation := f.drift
+	if compensation < 0 {
+		compensation = 0
+	} else if compensation > f.wait {
+		compensation = f.wait
+	}
+	deadline := f.frameStart.Add(f.wait - compensation)
+
+	coarse := deadline.Sub(f.clock.Now()) - f.spinThreshold - f.oversleep
+	if coarse > 0 {
+		before := f.clock.Now()
+		f.clock.Sleep(coarse)
+		f.trackOversleep(f.clock.Since(before) - coarse)
+	}
+	f.spin(deadline)
+	f.drift = f.clock.Since(deadline)
+}
+
+// trackOversleep updates the estimate of how much sleeps overshoot.
+// The estimate rises at once and falls slowly, as waking up late
+// is worse than spinning for longer.
+func (f *FpsLimiter) trackOversleep(oversleep time.Duration) {
+	if oversleep < 0 {
+		oversleep = 0
+	}
+	if oversleep > f.oversleep {
+		f.oversleep = oversleep
+	} else {
+		f.oversleep -= (f.oversleep - oversleep) / 8
+	}
+}
+
+// spin busy-waits until the deadline. If the clock does not move
+// while spinning, as a ManualClock does not, the rest is slept.
+func (f *FpsLimiter) spin(deadline time.Time) {
+	last := f.clock.Now()
+	still := 0
+	for now := last; now.Before(deadline); now = f.clock.Now() {
+		if now.Equal(last) {
+			still++
+			if still >= maxStillSpins {
+				f.clock.Sleep(deadline.Sub(now))
+				return
+			}
+		} else {
+			still = 0
+			last = now
+		}
+		runtime.Gosched()
+	}
 }
 
 // SetLimit sets the fps limit.
@@ -29,7 +29,7 @@ func TestFpsLimiter(t *testing.T) {
 		t.Run(funcName(t, test), func(t *testing.T) {
 			t.Parallel()
 			clock := NewFakeClock()
-			fps := newFpsLimiterClock(fpsTestFps, clock)
+			fps := NewFpsLimiterClock(fpsTestFps, clock)
 			test(t, fps, clock)
 		})
 	}
@@ -114,7 +114,7 @@ func BenchmarkFpsLimiter_Loop(b *testing.B) {
 	for _, loopSize := range loopSizes {
 		loopSize := loopSize
 		b.Run(fmt.Sprintf("loopSize[%d]", loopSize), func(b *testing.B) {
-			fps := newFpsLimiterClock(60, NewFakeClock())
+			fps := NewFpsLimiterClock(60, NewFakeClock())
 			for i := 0; i < b.N; i++ {
 				for j := 0; j < loopSize; j++ {
 					fps.StartFrame()
@@ -124,3 +124,128 @@ func BenchmarkFpsLimiter_Loop(b *testing.B) {
 		})
 	}
 }
+
+// runFrames runs empty frames and returns the
+// time deltas returned by StartFrame.
+func runFrames(fps *FpsLimiter, frames int) []time.Duration {
+	deltas := make([]time.Duration, 0, frames)
+	for i := 0; i < frames; i++ {
+		deltas = append(deltas, fps.startFrame())
+		fps.WaitForNextFrame()
+	}
+	return deltas
+}
+
+func TestFpsLimiter_FrameWaitSleep_overshoot(t *testing.T) {
+	clock := newOvershootClock(3*time.Millisecond, 0)
+	fps := NewFpsLimiterClock(100, clock)
+
+	deltas := runFrames(fps, 10)
+
+	for _, delta := range deltas[1:] {
+		assert.Equal(t, 13*time.Millisecond, delta)
+	}
+	assert.Equal(t, 3*time.Millisecond, fps.Drift())
+}
+
+func TestFpsLimiter_FrameWaitHybrid(t *testing.T) {
+	clock := newOvershootClock(3*time.Millisecond, 10*time.Microsecond)
+	fps := NewFpsLimiterClock(100, clock)
+	fps.SetFrameWait(FrameWaitHybrid)
+	fps.SetSpinThreshold(time.Millisecond)
+
+	deltas := runFrames(fps, 100)
+
+	assert.Equal(t, FrameWaitHybrid, fps.FrameWait())
+	var total time.Duration
+	for _, delta := range deltas[1:] {
+		total += delta
+	}
+	average := total / time.Duration(len(deltas)-1)
+	assert.InDelta(t, float64(10*time.Millisecond), float64(average), float64(50*time.Microsecond))
+	// once the overshoot is known, frames are on time
+	for _, delta := range deltas[10:] {
+		assert.InDelta(t, float64(10*time.Millisecond), float64(delta), float64(50*time.Microsecond))
+	}
+	assert.True(t, fps.Drift() >= 0 && fps.Drift() < 50*time.Microsecond, "drift %v", fps.Drift())
+	assert.Equal(t, 100, clock.sleeps, "every frame sleeps coarsely")
+}
+
+func TestFpsLimiter_FrameWaitHybrid_compensates(t *testing.T) {
+	clock := newOvershootClock(0, 10*time.Microsecond)
+	fps := NewFpsLimiterClock(100, clock)
+	fps.SetFrameWait(FrameWaitHybrid)
+
+	fps.startFrame()
+	clock.Advance(14 * time.Millisecond)
+	fps.WaitForNextFrame()
+	late := fps.Drift()
+	fps.startFrame()
+	fps.WaitForNextFrame()
+
+	assert.True(t, late >= 4*time.Millisecond)
+	assert.True(t, fps.Drift() < 50*time.Microsecond)
+	assert.InDelta(t, float64(10*time.Millisecond-late), float64(fps.startFrame()), float64(50*time.Microsecond))
+}
+
+func TestFpsLimiter_FrameWaitHybrid_manualClock(t *testing.T) {
+	clock := NewManualClock()
+	fps := NewFpsLimiterClock(50, clock)
+	fps.SetFrameWait(FrameWaitHybrid)
+
+	deltas := runFrames(fps, 3)
+
+	assert.Equal(t, []time.Duration{0, 20 * time.Millisecond, 20 * time.Millisecond}, deltas)
+}
+
+func TestFpsLimiter_DeltaClamp(t *testing.T) {
+	clock := NewFakeClock()
+	fps := NewFpsLimiterClock(fpsTestFps, clock)
+	fps.SetDeltaPolicy(DeltaPolicy{Mode: DeltaClamp, Max: 100 * time.Millisecond})
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, 0.1, fps.StartFrame())
+	clock.Advance(20 * time.Millisecond)
+	assert.Equal(t, 0.02, fps.StartFrame())
+}
+
+func TestFpsLimiter_DeltaSmooth(t *testing.T) {
+	clock := NewFakeClock()
+	fps := NewFpsLimiterClock(fpsTestFps, clock)
+	fps.SetDeltaPolicy(DeltaPolicy{Mode: DeltaSmooth, Max: 100 * time.Millisecond, Window: 2})
+
+	clock.Advance(time.Second)
+	assert.Equal(t, 100*time.Millisecond, fps.startFrame(), "nothing to smooth with yet")
+	for _, delta := range []time.Duration{10, 20, 30} {
+		clock.Advance(delta * time.Millisecond)
+		assert.Equal(t, delta*time.Millisecond, fps.startFrame())
+	}
+	clock.Advance(time.Second)
+	assert.Equal(t, 25*time.Millisecond, fps.startFrame())
+	assert.Equal(t, 2, fps.DeltaPolicy().Window)
+}
+
+func TestFpsLimiter_DeltaRaw(t *testing.T) {
+	clock := NewFakeClock()
+	fps := NewFpsLimiterClock(fpsTestFps, clock)
+	fps.SetDeltaPolicy(DeltaPolicy{Mode: DeltaRaw, Max: time.Millisecond})
+
+	clock.Advance(time.Second)
+
+	assert.Equal(t, 1.0, fps.StartFrame())
+	assert.Equal(t, defaultDeltaWindow, fps.DeltaPolicy().Window)
+}
+
+func TestFpsLimiter_pausedScaledClock(t *testing.T) {
+	for _, frameWait := range []FrameWait{FrameWaitSleep, FrameWaitHybrid} {
+		manual := NewManualClock()
+		fps := NewFpsLimiterClock(50, NewScaledClock(manual, 0))
+		fps.SetFrameWait(frameWait)
+		start := manual.Now()
+
+		deltas := runFrames(fps, 5)
+
+		assert.Equal(t, make([]time.Duration, 5), deltas)
+		assert.True(t, manual.Since(start) >= 100*time.Millisecond, "frames still wait in real time")
+	}
+}
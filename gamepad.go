@@ -0,0 +1,457 @@
+package wo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/faiface/mainthread"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/pkg/errors"
+)
+
+// DefaultGamepadDeadzone is the deadzone of the gamepads of a window
+// opened with DefaultWindowOptions.
+const DefaultGamepadDeadzone = 0.2
+
+// Joystick is one of the joysticks or gamepads that can be connected.
+type Joystick int
+
+// Joysticks as numbered by GLFW.
+const (
+	Joystick1 Joystick = iota
+	Joystick2
+	Joystick3
+	Joystick4
+	Joystick5
+	Joystick6
+	Joystick7
+	Joystick8
+	Joystick9
+	Joystick10
+	Joystick11
+	Joystick12
+	Joystick13
+	Joystick14
+	Joystick15
+	Joystick16
+	JoystickLast = Joystick16
+)
+
+// AnyJoystick, in a GamepadButton or GamepadAxis, stands for every
+// connected joystick, such as to bind an action to the first button
+// of whichever gamepad a player uses.
+const AnyJoystick Joystick = -1
+
+// String returns the name of a Joystick, such as "Joystick1".
+func (js Joystick) String() string {
+	if js == AnyJoystick {
+		return "AnyJoystick"
+	}
+	return fmt.Sprintf("Joystick%d", int(js)+1)
+}
+
+// GamepadButton is a button of a joystick.
+type GamepadButton struct {
+	Joystick Joystick
+	Button   int
+}
+
+// String returns the name of a GamepadButton, such as "Joystick1:Button0".
+func (b GamepadButton) String() string {
+	return fmt.Sprintf("%s:Button%d", b.Joystick, b.Button)
+}
+
+// GamepadAxis is an analog axis of a joystick.
+type GamepadAxis struct {
+	Joystick Joystick
+	Axis     int
+	// Invert reverses the axis, such as for a vertical
+	// axis that reports up as -1.
+	Invert bool
+}
+
+// String returns the name of a GamepadAxis, such as "Joystick1:Axis0",
+// starting with "-" if it is inverted.
+func (a GamepadAxis) String() string {
+	name := fmt.Sprintf("%s:Axis%d", a.Joystick, a.Axis)
+	if a.Invert {
+		return "-" + name
+	}
+	return name
+}
+
+// ParseGamepadButton returns the GamepadButton with a name given by
+// GamepadButton.String, such as "Joystick1:Button0" or "AnyJoystick:Button3".
+func ParseGamepadButton(name string) (GamepadButton, error) {
+	var button GamepadButton
+	joystick, index, err := parseJoystickPart(name, "Button")
+	if err != nil {
+		return button, errors.Wrapf(err, "unknown gamepad button %q", name)
+	}
+	return GamepadButton{Joystick: joystick, Button: index}, nil
+}
+
+// ParseGamepadAxis returns the GamepadAxis with a name given by
+// GamepadAxis.String, such as "Joystick1:Axis0" or "-AnyJoystick:Axis1".
+func ParseGamepadAxis(name string) (GamepadAxis, error) {
+	invert := strings.HasPrefix(name, "-")
+	joystick, index, err := parseJoystickPart(strings.TrimPrefix(name, "-"), "Axis")
+	if err != nil {
+		return GamepadAxis{}, errors.Wrapf(err, "unknown gamepad axis %q", name)
+	}
+	return GamepadAxis{Joystick: joystick, Axis: index, Invert: invert}, nil
+}
+
+// parseJoystickPart parses names such as "Joystick1:Button0"
+// into their Joystick and the index of their part.
+func parseJoystickPart(name, part string) (Joystick, int, error) {
+	parts := strings.Split(name, ":")
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], part) {
+		return 0, 0, errors.Errorf("expected Joystick<n>:%s<n>", part)
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(parts[1], part))
+	if err != nil || index < 0 {
+		return 0, 0, errors.Errorf("invalid %s number", strings.ToLower(part))
+	}
+	if parts[0] == AnyJoystick.String() {
+		return AnyJoystick, index, nil
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(parts[0], "Joystick"))
+	if err != nil || !strings.HasPrefix(parts[0], "Joystick") || number < 1 || Joystick(number-1) > JoystickLast {
+		return 0, 0, errors.New("invalid joystick")
+	}
+	return Joystick(number - 1), index, nil
+}
+
+// GamepadInput defines the mechanism to read user input from joysticks
+// and gamepads. Buttons and axes are numbered in the order the system
+// reports them, which depends on the model of the joystick.
+//
+// An Input that also reads gamepads implements GamepadInput; use
+// InputGamepads to read the gamepads of any Input.
+type GamepadInput interface {
+	// JoystickPresent returns whether a joystick is connected.
+	JoystickPresent(js Joystick) bool
+
+	// JoystickJustConnected returns whether a joystick has just been connected.
+	JoystickJustConnected(js Joystick) bool
+
+	// JoystickJustDisconnected returns whether a joystick has just been disconnected.
+	JoystickJustDisconnected(js Joystick) bool
+
+	// JoystickName returns the name of a joystick given by the system.
+	JoystickName(js Joystick) string
+
+	// JoystickButtonCount returns the number of buttons of a joystick.
+	JoystickButtonCount(js Joystick) int
+
+	// JoystickAxisCount returns the number of analog axes of a joystick.
+	JoystickAxisCount(js Joystick) int
+
+	// JoystickPressed returns whether a button of a joystick is currently pressed down.
+	JoystickPressed(js Joystick, button int) bool
+
+	// JoystickJustPressed returns whether a button of a joystick has just been pressed down.
+	JoystickJustPressed(js Joystick, button int) bool
+
+	// JoystickJustReleased returns whether a button of a joystick has just been released.
+	JoystickJustReleased(js Joystick, button int) bool
+
+	// JoystickAxis returns the position of an analog axis of a joystick,
+	// from -1 to 1, with positions within the deadzone reported as 0.
+	JoystickAxis(js Joystick, axis int) float64
+}
+
+// InputGamepads returns the GamepadInput of an Input. An Input
+// that does not read gamepads never has a joystick connected.
+func InputGamepads(input Input) GamepadInput {
+	if gamepads, ok := input.(GamepadInput); ok {
+		return gamepads
+	}
+	return nullGamepads{}
+}
+
+// WithGamepads combines an Input with Gamepads into an Input that
+// also reads the gamepads, such as to give a HeadlessWindow gamepads
+// backed by a FakeGamepadBackend.
+func WithGamepads(input Input, gamepads *Gamepads) Input {
+	return &gamepadInput{
+		Input:    input,
+		Gamepads: gamepads,
+	}
+}
+
+type gamepadInput struct {
+	Input
+	*Gamepads
+}
+
+// JoystickState is the raw state of a connected joystick.
+type JoystickState struct {
+	Joystick Joystick
+	Name     string
+	Buttons  []bool
+	// Axes are the positions of the analog axes, from -1 to 1.
+	Axes []float64
+}
+
+// GamepadBackend reads the raw state of joysticks from the system.
+type GamepadBackend interface {
+	// Joysticks returns the state of every connected joystick.
+	Joysticks() []JoystickState
+}
+
+// Gamepads reads joysticks from a GamepadBackend once every frame
+// and reports them as a GamepadInput. It keeps the state of the
+// previous frame to report buttons that were just pressed and
+// joysticks that were just connected.
+type Gamepads struct {
+	backend  GamepadBackend
+	deadzone float64
+
+	current  [JoystickLast + 1]*JoystickState
+	previous [JoystickLast + 1]*JoystickState
+}
+
+var _ GamepadInput = &Gamepads{}
+
+// NewGamepads creates Gamepads that read from a GamepadBackend, with
+// the DefaultGamepadDeadzone. No joysticks are present until the first
+// call to Update.
+func NewGamepads(backend GamepadBackend) *Gamepads {
+	return &Gamepads{
+		backend:  backend,
+		deadzone: DefaultGamepadDeadzone,
+	}
+}
+
+// SetDeadzone sets how far from the center an analog axis must be moved,
+// from 0 to 1, before it is reported as moved. Worn joysticks rarely
+// rest exactly at their center. Positions beyond the deadzone are
+// rescaled so that axes still move smoothly from 0 to 1.
+func (g *Gamepads) SetDeadzone(deadzone float64) {
+	g.deadzone = math.Max(0, math.Min(deadzone, 1))
+}
+
+// Deadzone returns how far from the center an analog axis
+// must be moved before it is reported as moved.
+func (g *Gamepads) Deadzone() float64 {
+	return g.deadzone
+}
+
+// Update reads the current state of the joysticks. It should be
+// called once every frame, as a Window does with its own Gamepads.
+func (g *Gamepads) Update() {
+	g.previous = g.current
+	g.current = [JoystickLast + 1]*JoystickState{}
+	for _, state := range g.backend.Joysticks() {
+		if state.Joystick >= 0 && state.Joystick <= JoystickLast {
+			state := state
+			g.current[state.Joystick] = &state
+		}
+	}
+}
+
+// Joysticks returns the connected joysticks in order.
+func (g *Gamepads) Joysticks() []Joystick {
+	var joysticks []Joystick
+	for js, state := range g.current {
+		if state != nil {
+			joysticks = append(joysticks, Joystick(js))
+		}
+	}
+	return joysticks
+}
+
+// state returns the current state of a joystick, or nil if it is not connected.
+func (g *Gamepads) state(js Joystick) *JoystickState {
+	if js < 0 || js > JoystickLast {
+		return nil
+	}
+	return g.current[js]
+}
+
+// previousState returns the state of a joystick in the previous
+// frame, or nil if it was not connected.
+func (g *Gamepads) previousState(js Joystick) *JoystickState {
+	if js < 0 || js > JoystickLast {
+		return nil
+	}
+	return g.previous[js]
+}
+
+func (g *Gamepads) JoystickPresent(js Joystick) bool {
+	return g.state(js) != nil
+}
+
+func (g *Gamepads) JoystickJustConnected(js Joystick) bool {
+	return g.state(js) != nil && g.previousState(js) == nil
+}
+
+func (g *Gamepads) JoystickJustDisconnected(js Joystick) bool {
+	return g.state(js) == nil && g.previousState(js) != nil
+}
+
+func (g *Gamepads) JoystickName(js Joystick) string {
+	if state := g.state(js); state != nil {
+		return state.Name
+	}
+	return ""
+}
+
+func (g *Gamepads) JoystickButtonCount(js Joystick) int {
+	if state := g.state(js); state != nil {
+		return len(state.Buttons)
+	}
+	return 0
+}
+
+func (g *Gamepads) JoystickAxisCount(js Joystick) int {
+	if state := g.state(js); state != nil {
+		return len(state.Axes)
+	}
+	return 0
+}
+
+func (g *Gamepads) JoystickPressed(js Joystick, button int) bool {
+	return joystickButton(g.state(js), button)
+}
+
+func (g *Gamepads) JoystickJustPressed(js Joystick, button int) bool {
+	return joystickButton(g.state(js), button) && !joystickButton(g.previousState(js), button)
+}
+
+func (g *Gamepads) JoystickJustReleased(js Joystick, button int) bool {
+	return !joystickButton(g.state(js), button) && joystickButton(g.previousState(js), button)
+}
+
+func (g *Gamepads) JoystickAxis(js Joystick, axis int) float64 {
+	state := g.state(js)
+	if state == nil || axis < 0 || axis >= len(state.Axes) {
+		return 0
+	}
+	return applyDeadzone(state.Axes[axis], g.deadzone)
+}
+
+// joystickButton returns whether a button of a joystick
+// is pressed in a state, which may be nil.
+func joystickButton(state *JoystickState, button int) bool {
+	return state != nil && button >= 0 && button < len(state.Buttons) && state.Buttons[button]
+}
+
+// applyDeadzone reports positions of an axis within the deadzone as 0,
+// and rescales positions beyond it to range from 0 to 1 again.
+func applyDeadzone(value, deadzone float64) float64 {
+	value = math.Max(-1, math.Min(value, 1))
+	magnitude := math.Abs(value)
+	if magnitude <= deadzone || deadzone >= 1 {
+		return 0
+	}
+	return math.Copysign((magnitude-deadzone)/(1-deadzone), value)
+}
+
+// glfwGamepads is a GamepadBackend reading joysticks from GLFW.
+type glfwGamepads struct{}
+
+func (glfwGamepads) Joysticks() []JoystickState {
+	var states []JoystickState
+	mainthread.Call(func() {
+		for js := Joystick1; js <= JoystickLast; js++ {
+			joy := glfw.Joystick(js)
+			if !glfw.JoystickPresent(joy) {
+				continue
+			}
+			state := JoystickState{
+				Joystick: js,
+				Name:     glfw.GetJoystickName(joy),
+			}
+			for _, button := range glfw.GetJoystickButtons(joy) {
+				state.Buttons = append(state.Buttons, glfw.Action(button) == glfw.Press)
+			}
+			for _, axis := range glfw.GetJoystickAxes(joy) {
+				state.Axes = append(state.Axes, float64(axis))
+			}
+			states = append(states, state)
+		}
+	})
+	return states
+}
+
+// FakeGamepadBackend is a GamepadBackend whose joysticks are connected
+// and moved by calling its methods, such as to test Scenes reading
+// gamepads without any hardware.
+type FakeGamepadBackend struct {
+	joysticks map[Joystick]*JoystickState
+}
+
+var _ GamepadBackend = &FakeGamepadBackend{}
+
+// NewFakeGamepadBackend creates a FakeGamepadBackend without any joysticks.
+func NewFakeGamepadBackend() *FakeGamepadBackend {
+	return &FakeGamepadBackend{
+		joysticks: make(map[Joystick]*JoystickState),
+	}
+}
+
+// Connect connects a joystick with the given number of buttons
+// and axes, all released and centered. A joystick that is already
+// connected is replaced.
+func (f *FakeGamepadBackend) Connect(js Joystick, name string, buttons, axes int) {
+	f.joysticks[js] = &JoystickState{
+		Joystick: js,
+		Name:     name,
+		Buttons:  make([]bool, buttons),
+		Axes:     make([]float64, axes),
+	}
+}
+
+// Disconnect disconnects a joystick.
+func (f *FakeGamepadBackend) Disconnect(js Joystick) {
+	delete(f.joysticks, js)
+}
+
+// SetButton presses or releases a button of a connected joystick.
+func (f *FakeGamepadBackend) SetButton(js Joystick, button int, pressed bool) {
+	if state, ok := f.joysticks[js]; ok && button >= 0 && button < len(state.Buttons) {
+		state.Buttons[button] = pressed
+	}
+}
+
+// SetAxis moves an analog axis of a connected joystick.
+func (f *FakeGamepadBackend) SetAxis(js Joystick, axis int, value float64) {
+	if state, ok := f.joysticks[js]; ok && axis >= 0 && axis < len(state.Axes) {
+		state.Axes[axis] = value
+	}
+}
+
+// Joysticks returns copies of the state of every connected joystick.
+func (f *FakeGamepadBackend) Joysticks() []JoystickState {
+	states := make([]JoystickState, 0, len(f.joysticks))
+	for _, state := range f.joysticks {
+		states = append(states, JoystickState{
+			Joystick: state.Joystick,
+			Name:     state.Name,
+			Buttons:  append([]bool(nil), state.Buttons...),
+			Axes:     append([]float64(nil), state.Axes...),
+		})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Joystick < states[j].Joystick })
+	return states
+}
+
+// nullGamepads is a GamepadInput without any joysticks.
+type nullGamepads struct{}
+
+func (nullGamepads) JoystickPresent(js Joystick) bool                  { return false }
+func (nullGamepads) JoystickJustConnected(js Joystick) bool            { return false }
+func (nullGamepads) JoystickJustDisconnected(js Joystick) bool         { return false }
+func (nullGamepads) JoystickName(js Joystick) string                   { return "" }
+func (nullGamepads) JoystickButtonCount(js Joystick) int               { return 0 }
+func (nullGamepads) JoystickAxisCount(js Joystick) int                 { return 0 }
+func (nullGamepads) JoystickPressed(js Joystick, button int) bool      { return false }
+func (nullGamepads) JoystickJustPressed(js Joystick, button int) bool  { return false }
+func (nullGamepads) JoystickJustReleased(js Joystick, button int) bool { return false }
+func (nullGamepads) JoystickAxis(js Joystick, axis int) float64        { return 0 }
@@ -0,0 +1,162 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ GamepadInput = nullGamepads{}
+	_ GamepadInput = &latchedInput{}
+)
+
+func newTestGamepads() (*FakeGamepadBackend, *Gamepads) {
+	backend := NewFakeGamepadBackend()
+	gamepads := NewGamepads(backend)
+	return backend, gamepads
+}
+
+func TestGamepads_hotPlug(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+
+	gamepads.Update()
+	assert.False(t, gamepads.JoystickPresent(Joystick2))
+
+	backend.Connect(Joystick2, "pad", 4, 2)
+	gamepads.Update()
+	assert.True(t, gamepads.JoystickPresent(Joystick2))
+	assert.True(t, gamepads.JoystickJustConnected(Joystick2))
+	assert.Equal(t, "pad", gamepads.JoystickName(Joystick2))
+	assert.Equal(t, 4, gamepads.JoystickButtonCount(Joystick2))
+	assert.Equal(t, 2, gamepads.JoystickAxisCount(Joystick2))
+	assert.Equal(t, []Joystick{Joystick2}, gamepads.Joysticks())
+
+	gamepads.Update()
+	assert.False(t, gamepads.JoystickJustConnected(Joystick2))
+
+	backend.Disconnect(Joystick2)
+	gamepads.Update()
+	assert.False(t, gamepads.JoystickPresent(Joystick2))
+	assert.True(t, gamepads.JoystickJustDisconnected(Joystick2))
+	assert.Equal(t, 0, gamepads.JoystickButtonCount(Joystick2))
+}
+
+func TestGamepads_buttons(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	backend.Connect(Joystick1, "pad", 2, 0)
+	gamepads.Update()
+
+	backend.SetButton(Joystick1, 1, true)
+	gamepads.Update()
+	assert.True(t, gamepads.JoystickPressed(Joystick1, 1))
+	assert.True(t, gamepads.JoystickJustPressed(Joystick1, 1))
+	assert.False(t, gamepads.JoystickPressed(Joystick1, 0))
+
+	gamepads.Update()
+	assert.True(t, gamepads.JoystickPressed(Joystick1, 1))
+	assert.False(t, gamepads.JoystickJustPressed(Joystick1, 1))
+
+	backend.SetButton(Joystick1, 1, false)
+	gamepads.Update()
+	assert.False(t, gamepads.JoystickPressed(Joystick1, 1))
+	assert.True(t, gamepads.JoystickJustReleased(Joystick1, 1))
+
+	assert.False(t, gamepads.JoystickPressed(Joystick1, 5))
+	assert.False(t, gamepads.JoystickPressed(Joystick(-3), 0))
+}
+
+func TestGamepads_deadzone(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	backend.Connect(Joystick1, "pad", 0, 2)
+	gamepads.SetDeadzone(0.2)
+
+	backend.SetAxis(Joystick1, 0, 0.1)
+	backend.SetAxis(Joystick1, 1, -0.6)
+	gamepads.Update()
+
+	assert.Equal(t, 0.0, gamepads.JoystickAxis(Joystick1, 0))
+	assert.InDelta(t, -0.5, gamepads.JoystickAxis(Joystick1, 1), 1e-9)
+
+	backend.SetAxis(Joystick1, 0, 1.5)
+	gamepads.Update()
+	assert.Equal(t, 1.0, gamepads.JoystickAxis(Joystick1, 0))
+	assert.Equal(t, 0.0, gamepads.JoystickAxis(Joystick1, 2))
+}
+
+func TestGamepads_Update_copiesState(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	backend.Connect(Joystick1, "pad", 1, 0)
+	gamepads.Update()
+
+	backend.SetButton(Joystick1, 0, true)
+
+	assert.False(t, gamepads.JoystickPressed(Joystick1, 0))
+}
+
+func TestInputGamepads(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	backend.Connect(Joystick1, "pad", 1, 0)
+	gamepads.Update()
+
+	assert.False(t, InputGamepads(newTestInput()).JoystickPresent(Joystick1))
+	assert.True(t, InputGamepads(WithGamepads(newTestInput(), gamepads)).JoystickPresent(Joystick1))
+}
+
+func TestLatchedInput_gamepads(t *testing.T) {
+	backend, gamepads := newTestGamepads()
+	latched := &latchedInput{Input: WithGamepads(newTestInput(), gamepads)}
+
+	backend.Connect(Joystick1, "pad", 1, 1)
+	backend.SetButton(Joystick1, 0, true)
+	backend.SetAxis(Joystick1, 0, 1)
+	gamepads.Update()
+	latched.latch()
+	gamepads.Update()
+	latched.latch()
+
+	assert.True(t, latched.JoystickJustConnected(Joystick1))
+	assert.True(t, latched.JoystickJustPressed(Joystick1, 0))
+	assert.True(t, latched.JoystickPressed(Joystick1, 0))
+	assert.Equal(t, 1.0, latched.JoystickAxis(Joystick1, 0))
+
+	latched.consume()
+
+	assert.False(t, latched.JoystickJustConnected(Joystick1))
+	assert.False(t, latched.JoystickJustPressed(Joystick1, 0))
+	assert.True(t, latched.JoystickPressed(Joystick1, 0))
+}
+
+func TestParseGamepadButton(t *testing.T) {
+	for _, button := range []GamepadButton{
+		{Joystick: Joystick1, Button: 0},
+		{Joystick: Joystick16, Button: 12},
+		{Joystick: AnyJoystick, Button: 3},
+	} {
+		parsed, err := ParseGamepadButton(button.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, button, parsed)
+	}
+
+	for _, name := range []string{"Joystick0:Button1", "Joystick17:Button1", "Joystick1:Axis1", "Joystick1:Button-1", "Button1"} {
+		_, err := ParseGamepadButton(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseGamepadAxis(t *testing.T) {
+	for _, axis := range []GamepadAxis{
+		{Joystick: Joystick2, Axis: 1},
+		{Joystick: AnyJoystick, Axis: 0, Invert: true},
+	} {
+		parsed, err := ParseGamepadAxis(axis.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, axis, parsed)
+	}
+	assert.Equal(t, "-AnyJoystick:Axis0", GamepadAxis{Joystick: AnyJoystick, Invert: true}.String())
+
+	_, err := ParseGamepadAxis("Joystick1:Button1")
+	assert.EqualError(t, err, `unknown gamepad axis "Joystick1:Button1": expected Joystick<n>:Axis<n>`)
+}
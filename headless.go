@@ -0,0 +1,129 @@
+package wo
+
+import (
+	"image"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"golang.org/x/image/colornames"
+)
+
+// HeadlessWindow is a Window that renders frames in software onto an
+// ImageCanvas instead of showing them on screen. It does not require
+// OpenGL, so Worlds using it can run in tests.
+type HeadlessWindow struct {
+	screen *ImageCanvas
+	input  Input
+
+	frames     int
+	maxFrames  int
+	closed     bool
+	fullscreen bool
+
+	// OnFrame, if not nil, is called with every frame the window displays,
+	// numbered from 1.
+	OnFrame func(frame int, img *image.RGBA)
+}
+
+var _ FullscreenWindow = &HeadlessWindow{}
+
+// NewHeadlessWindow creates a HeadlessWindow of the given size that
+// reads from input. A nil Input never reports any user input.
+func NewHeadlessWindow(width, height int, input Input) *HeadlessWindow {
+	if input == nil {
+		input = nullInput{}
+	}
+	return &HeadlessWindow{
+		screen: NewImageCanvas(pixel.R(0, 0, float64(width), float64(height))),
+		input:  input,
+	}
+}
+
+// SetMaxFrames closes the window once it has displayed the given
+// number of frames. A limit of 0 never closes the window.
+func (h *HeadlessWindow) SetMaxFrames(maxFrames int) {
+	h.maxFrames = maxFrames
+}
+
+// SetSize resizes the window, like a user resizing a window on screen.
+// The last frame displayed by the window is lost.
+func (h *HeadlessWindow) SetSize(width, height int) {
+	h.screen = NewImageCanvas(pixel.R(0, 0, float64(width), float64(height)))
+}
+
+// Close closes the window. A World stops running before its next frame.
+func (h *HeadlessWindow) Close() {
+	h.closed = true
+}
+
+// SetFullscreen marks the window as fullscreen. A HeadlessWindow
+// has no monitor, so its size does not change.
+func (h *HeadlessWindow) SetFullscreen(fullscreen bool) {
+	h.fullscreen = fullscreen
+}
+
+// Fullscreen returns whether the window is marked as fullscreen.
+func (h *HeadlessWindow) Fullscreen() bool {
+	return h.fullscreen
+}
+
+// Closed returns whether the window has been closed or has
+// displayed its maximum number of frames.
+func (h *HeadlessWindow) Closed() bool {
+	return h.closed || (h.maxFrames > 0 && h.frames >= h.maxFrames)
+}
+
+// Bounds returns the rectangle of the window.
+func (h *HeadlessWindow) Bounds() pixel.Rect {
+	return h.screen.Bounds()
+}
+
+// Input returns the Input of the window.
+func (h *HeadlessWindow) Input() Input {
+	return h.input
+}
+
+// NewCanvas creates an ImageCanvas.
+func (h *HeadlessWindow) NewCanvas(bounds pixel.Rect) Canvas {
+	return NewImageCanvas(bounds)
+}
+
+// Display renders a Canvas as the next frame of the window.
+func (h *HeadlessWindow) Display(canvas Canvas, matrix pixel.Matrix) {
+	h.screen.Clear(colornames.Black)
+	h.screen.SetMatrix(pixel.IM)
+	canvas.Draw(h.screen, matrix)
+	h.frames++
+	if h.OnFrame != nil {
+		h.OnFrame(h.frames, h.screen.Image())
+	}
+}
+
+// Frames returns the number of frames the window has displayed.
+func (h *HeadlessWindow) Frames() int {
+	return h.frames
+}
+
+// Image returns the last frame the window displayed.
+func (h *HeadlessWindow) Image() *image.RGBA {
+	return h.screen.Image()
+}
+
+// NewHeadlessWorld creates a World that displays its Scenes on a
+// HeadlessWindow. It uses a ManualClock, so time only passes between
+// frames and every frame is updated with a time-delta of exactly 1/fps
+// seconds no matter how long it took to render.
+func NewHeadlessWorld(window *HeadlessWindow, scenes map[string]SceneFactory) *World {
+	return newWorld(window, NewManualClock(), scenes)
+}
+
+// nullInput is an Input without any user input.
+type nullInput struct{}
+
+func (nullInput) Pressed(button ...pixelgl.Button) bool      { return false }
+func (nullInput) JustPressed(button ...pixelgl.Button) bool  { return false }
+func (nullInput) JustReleased(button ...pixelgl.Button) bool { return false }
+func (nullInput) Repeated(button ...pixelgl.Button) bool     { return false }
+func (nullInput) MousePosition() pixel.Vec                   { return pixel.ZV }
+func (nullInput) MouseScroll() pixel.Vec                     { return pixel.ZV }
+func (nullInput) Typed() string                              { return "" }
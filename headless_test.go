@@ -0,0 +1,238 @@
+package wo
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/stretchr/testify/assert"
+)
+
+// colorScene fills the canvas with a color and records each time-delta.
+type colorScene struct {
+	*testScene
+	color color.Color
+	dts   []float64
+}
+
+func (s *colorScene) Update(dt float64, input Input) SceneResult {
+	s.dts = append(s.dts, dt)
+	return s.testScene.Update(dt, input)
+}
+
+func (s *colorScene) Draw(canvas Canvas) {
+	s.testScene.Draw(canvas)
+	im := imdraw.New(nil)
+	im.Color = s.color
+	im.Push(canvas.Bounds().Min, canvas.Bounds().Max)
+	im.Rectangle(0)
+	im.Draw(canvas)
+}
+
+func TestHeadlessWorld_RunScene(t *testing.T) {
+	scene := &colorScene{testScene: newTestScene("red"), color: opaqueRed}
+	window := NewHeadlessWindow(4, 3, nil)
+	window.SetMaxFrames(5)
+
+	var frames []*image.RGBA
+	window.OnFrame = func(frame int, img *image.RGBA) {
+		assert.Equal(t, len(frames)+1, frame)
+		frames = append(frames, img)
+	}
+
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"red": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.Color = nil
+
+	result, err := world.RunScene("red")
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultWindowClosed, result)
+	assert.Equal(t, 5, window.Frames())
+	assert.Equal(t, 5, scene.updates)
+	assert.Equal(t, 5, scene.draws)
+	assert.Len(t, frames, 5)
+	for _, img := range frames {
+		assert.Equal(t, image.Rect(0, 0, 4, 3), img.Bounds())
+		assert.Equal(t, opaqueRed, img.RGBAAt(0, 0))
+		assert.Equal(t, opaqueRed, img.RGBAAt(3, 2))
+	}
+}
+
+func TestHeadlessWorld_fixedFrameTime(t *testing.T) {
+	scene := &colorScene{testScene: newTestScene("scene"), color: opaqueRed}
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(4)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFps(50)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	assert.Len(t, scene.dts, 4)
+	assert.Equal(t, 0.0, scene.dts[0])
+	for _, dt := range scene.dts[1:] {
+		assert.InDelta(t, 0.02, dt, 1e-9)
+	}
+}
+
+func TestHeadlessWorld_Run(t *testing.T) {
+	first := &colorScene{testScene: newTestScene("first", testResultA), color: opaqueRed}
+	second := &colorScene{testScene: newTestScene("second", SceneResultNone, SceneResultWindowClosed), color: opaqueBlue}
+	window := NewHeadlessWindow(2, 2, nil)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"first": func(canvas Canvas) (Scene, error) {
+			return first, nil
+		},
+		"second": func(canvas Canvas) (Scene, error) {
+			return second, nil
+		},
+	})
+	world.Route("first", testResultA, "second")
+
+	err := world.Run("first")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.updates)
+	assert.Equal(t, 0, first.draws)
+	assert.Equal(t, 2, second.updates)
+	assert.Equal(t, 1, window.Frames())
+	assert.Equal(t, opaqueBlue, window.Image().RGBAAt(1, 1))
+}
+
+func TestHeadlessWindow_Close(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+
+	assert.False(t, window.Closed())
+	window.Close()
+	assert.True(t, window.Closed())
+}
+
+func TestHeadlessWindow_Display(t *testing.T) {
+	window := NewHeadlessWindow(2, 2, nil)
+	canvas := window.NewCanvas(pixel.R(0, 0, 1, 1))
+	canvas.Clear(opaqueGreen)
+
+	window.Display(canvas, pixel.IM.Scaled(pixel.ZV, 2).Moved(pixel.V(1, 1)))
+
+	img := window.Image()
+	assert.Equal(t, 1, window.Frames())
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			assert.Equal(t, opaqueGreen, img.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestNullInput(t *testing.T) {
+	var input Input = nullInput{}
+	assert.False(t, input.Pressed(0))
+	assert.Equal(t, pixel.ZV, input.MousePosition())
+	assert.Equal(t, "", input.Typed())
+}
+
+func TestHeadlessWorld_resize(t *testing.T) {
+	opaqueBlack := color.RGBA{A: 0xff}
+	scene := &colorScene{testScene: newTestScene("scene"), color: opaqueBlue}
+	window := NewHeadlessWindow(2, 2, nil)
+	window.SetMaxFrames(2)
+	window.OnFrame = func(frame int, img *image.RGBA) {
+		if frame == 1 {
+			window.SetSize(6, 4)
+		}
+	}
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFitMode(FitLetterbox)
+
+	_, err := world.RunScene("scene")
+
+	img := window.Image()
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 6, 4), img.Bounds())
+	for y := 0; y < 4; y++ {
+		assert.Equal(t, opaqueBlack, img.RGBAAt(0, y))
+		assert.Equal(t, opaqueBlue, img.RGBAAt(1, y))
+		assert.Equal(t, opaqueBlue, img.RGBAAt(4, y))
+		assert.Equal(t, opaqueBlack, img.RGBAAt(5, y))
+	}
+}
+
+func TestHeadlessWorld_ToggleFullscreen(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, nil)
+
+	assert.False(t, world.Fullscreen())
+
+	world.ToggleFullscreen()
+	assert.True(t, world.Fullscreen())
+	assert.True(t, window.Fullscreen())
+
+	world.SetFullscreen(false)
+	assert.False(t, world.Fullscreen())
+}
+
+func TestWorld_SetClock(t *testing.T) {
+	scene := &colorScene{testScene: newTestScene("scene"), color: opaqueRed}
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(3)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFps(50)
+	clock := NewScaledClock(NewManualClock(), 2)
+	world.SetClock(clock)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	assert.Equal(t, clock, world.Clock())
+	assert.Len(t, scene.dts, 3)
+	for _, dt := range scene.dts[1:] {
+		assert.InDelta(t, 0.02, dt, 1e-9)
+	}
+}
+
+// countingReloader counts how many times it reloads.
+type countingReloader struct {
+	reloads int
+}
+
+func (r *countingReloader) Reload() ([]string, error) {
+	r.reloads++
+	return []string{"asset"}, errors.New("reload failed")
+}
+
+func TestHeadlessWorld_SetAssetReloader(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(10)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return newTestScene("scene"), nil
+		},
+	})
+	world.SetFps(50)
+	reloader := &countingReloader{}
+	world.SetAssetReloader(reloader, 60*time.Millisecond)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err, "reload errors do not end the scene")
+	assert.Equal(t, 4, reloader.reloads)
+}
@@ -0,0 +1,273 @@
+package wo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/faiface/pixel"
+)
+
+// ImageCanvas is a Canvas that is rasterized in software instead
+// of with OpenGL. Its content can be read back as an image.RGBA,
+// which makes it useful for tests and headless Worlds.
+//
+// Triangles are filled by sampling the center of each pixel and
+// pictures are sampled with nearest-neighbour filtering. Colors are
+// blended onto the canvas using premultiplied alpha, like pixelgl.
+type ImageCanvas struct {
+	bounds pixel.Rect
+	width  int
+	height int
+
+	// pix holds the premultiplied colors of the canvas,
+	// starting with the bottom row.
+	pix []pixel.RGBA
+
+	matrix pixel.Matrix
+	mask   pixel.RGBA
+}
+
+var _ Canvas = &ImageCanvas{}
+
+// NewImageCanvas creates a transparent ImageCanvas with the given bounds.
+func NewImageCanvas(bounds pixel.Rect) *ImageCanvas {
+	bounds = bounds.Norm()
+	width := int(math.Ceil(bounds.W()))
+	height := int(math.Ceil(bounds.H()))
+	return &ImageCanvas{
+		bounds: bounds,
+		width:  width,
+		height: height,
+		pix:    make([]pixel.RGBA, width*height),
+		matrix: pixel.IM,
+		mask:   pixel.Alpha(1),
+	}
+}
+
+// Bounds returns the rectangle of the canvas.
+func (c *ImageCanvas) Bounds() pixel.Rect {
+	return c.bounds
+}
+
+// SetMatrix sets the Matrix that every point is projected by
+// when triangles are drawn.
+func (c *ImageCanvas) SetMatrix(m pixel.Matrix) {
+	c.matrix = m
+}
+
+// SetColorMask sets the color that every drawn color is multiplied by.
+// A nil mask is white, which does not change colors.
+func (c *ImageCanvas) SetColorMask(mask color.Color) {
+	if mask == nil {
+		c.mask = pixel.Alpha(1)
+		return
+	}
+	c.mask = pixel.ToRGBA(mask)
+}
+
+// Clear fills the whole canvas with a single color. The Matrix
+// and color mask do not apply.
+func (c *ImageCanvas) Clear(col color.Color) {
+	rgba := pixel.ToRGBA(col)
+	for i := range c.pix {
+		c.pix[i] = rgba
+	}
+}
+
+// Color returns the color of the pixel of the canvas at the given
+// position, or transparent if the position is outside of the canvas.
+func (c *ImageCanvas) Color(at pixel.Vec) pixel.RGBA {
+	x, y, ok := c.index(at)
+	if !ok {
+		return pixel.Alpha(0)
+	}
+	return c.pix[y*c.width+x]
+}
+
+// Draw draws the content of the canvas onto another Target like a
+// sprite centered at the origin, transformed by the given Matrix.
+func (c *ImageCanvas) Draw(t pixel.Target, matrix pixel.Matrix) {
+	pixel.NewSprite(c, c.bounds).Draw(t, matrix)
+}
+
+// Image returns a copy of the content of the canvas. The top row
+// of the canvas is the first row of the image.
+func (c *ImageCanvas) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+	for y := 0; y < c.height; y++ {
+		for x := 0; x < c.width; x++ {
+			col := c.pix[y*c.width+x]
+			img.SetRGBA(x, c.height-1-y, color.RGBA{
+				R: colorByte(col.R),
+				G: colorByte(col.G),
+				B: colorByte(col.B),
+				A: colorByte(col.A),
+			})
+		}
+	}
+	return img
+}
+
+// MakeTriangles creates TargetTriangles that are drawn onto the canvas.
+func (c *ImageCanvas) MakeTriangles(t pixel.Triangles) pixel.TargetTriangles {
+	tri := &imageTriangles{
+		TrianglesData: pixel.MakeTrianglesData(t.Len()),
+		dst:           c,
+	}
+	tri.Update(t)
+	return tri
+}
+
+// MakePicture creates a TargetPicture that is sampled when drawing
+// triangles onto the canvas.
+func (c *ImageCanvas) MakePicture(p pixel.Picture) pixel.TargetPicture {
+	sampler, ok := p.(pixel.PictureColor)
+	if !ok {
+		sampler = pixel.PictureDataFromPicture(p)
+	}
+	return &imagePicture{
+		Picture: p,
+		sampler: sampler,
+		dst:     c,
+	}
+}
+
+// index returns the pixel of the canvas at the given position.
+func (c *ImageCanvas) index(at pixel.Vec) (x, y int, ok bool) {
+	local := at.Sub(c.bounds.Min)
+	x = int(math.Floor(local.X))
+	y = int(math.Floor(local.Y))
+	ok = x >= 0 && y >= 0 && x < c.width && y < c.height
+	return x, y, ok
+}
+
+// rasterize fills every triangle, sampling a picture if it is not nil.
+func (c *ImageCanvas) rasterize(tri *pixel.TrianglesData, pic pixel.PictureColor) {
+	data := *tri
+	for i := 0; i+2 < len(data); i += 3 {
+		c.fillTriangle(
+			c.vertex(data, i),
+			c.vertex(data, i+1),
+			c.vertex(data, i+2),
+			pic,
+		)
+	}
+}
+
+// rasterVertex is a vertex of a triangle in the local
+// coordinates of the canvas.
+type rasterVertex struct {
+	pos       pixel.Vec
+	color     pixel.RGBA
+	pic       pixel.Vec
+	intensity float64
+}
+
+func (c *ImageCanvas) vertex(data pixel.TrianglesData, i int) rasterVertex {
+	return rasterVertex{
+		pos:       c.matrix.Project(data[i].Position).Sub(c.bounds.Min),
+		color:     data[i].Color,
+		pic:       data[i].Picture,
+		intensity: data[i].Intensity,
+	}
+}
+
+// fillTriangle blends every pixel whose center lies within the triangle.
+// Pixels centered exactly on an edge are filled only for top and left
+// edges so that triangles sharing an edge never blend a pixel twice.
+func (c *ImageCanvas) fillTriangle(v0, v1, v2 rasterVertex, pic pixel.PictureColor) {
+	area := edgeFunction(v0.pos, v1.pos, v2.pos)
+	if area == 0 {
+		return
+	}
+	if area < 0 {
+		v1, v2 = v2, v1
+		area = -area
+	}
+
+	minX := math.Max(0, math.Floor(math.Min(v0.pos.X, math.Min(v1.pos.X, v2.pos.X))))
+	minY := math.Max(0, math.Floor(math.Min(v0.pos.Y, math.Min(v1.pos.Y, v2.pos.Y))))
+	maxX := math.Min(float64(c.width), math.Ceil(math.Max(v0.pos.X, math.Max(v1.pos.X, v2.pos.X))))
+	maxY := math.Min(float64(c.height), math.Ceil(math.Max(v0.pos.Y, math.Max(v1.pos.Y, v2.pos.Y))))
+
+	topLeft0 := topLeftEdge(v1.pos, v2.pos)
+	topLeft1 := topLeftEdge(v2.pos, v0.pos)
+	topLeft2 := topLeftEdge(v0.pos, v1.pos)
+
+	for y := int(minY); y < int(maxY); y++ {
+		for x := int(minX); x < int(maxX); x++ {
+			p := pixel.V(float64(x)+0.5, float64(y)+0.5)
+			w0 := edgeFunction(v1.pos, v2.pos, p)
+			w1 := edgeFunction(v2.pos, v0.pos, p)
+			w2 := edgeFunction(v0.pos, v1.pos, p)
+			if !insideEdge(w0, topLeft0) || !insideEdge(w1, topLeft1) || !insideEdge(w2, topLeft2) {
+				continue
+			}
+			l0, l1, l2 := w0/area, w1/area, w2/area
+
+			col := v0.color.Scaled(l0).Add(v1.color.Scaled(l1)).Add(v2.color.Scaled(l2))
+			intensity := v0.intensity*l0 + v1.intensity*l1 + v2.intensity*l2
+			if pic != nil && intensity != 0 {
+				at := v0.pic.Scaled(l0).Add(v1.pic.Scaled(l1)).Add(v2.pic.Scaled(l2))
+				texel := pic.Color(at)
+				col = col.Scaled(1 - intensity).Add(col.Mul(texel).Scaled(intensity))
+			}
+			c.blend(x, y, col.Mul(c.mask))
+		}
+	}
+}
+
+// blend composes a premultiplied color over a pixel of the canvas.
+func (c *ImageCanvas) blend(x, y int, src pixel.RGBA) {
+	i := y*c.width + x
+	c.pix[i] = src.Add(c.pix[i].Scaled(1 - src.A))
+}
+
+// edgeFunction returns twice the signed area of the triangle a, b, p.
+// It is positive when p is to the left of the edge from a to b.
+func edgeFunction(a, b, p pixel.Vec) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+// topLeftEdge returns whether the edge from a to b of a counter-clockwise
+// triangle is a left edge or a top edge.
+func topLeftEdge(a, b pixel.Vec) bool {
+	return b.Y < a.Y || (b.Y == a.Y && b.X < a.X)
+}
+
+func insideEdge(w float64, topLeft bool) bool {
+	return w > 0 || (w == 0 && topLeft)
+}
+
+// colorByte converts a color component in the range [0, 1] to a byte.
+func colorByte(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(1, v))*255 + 0.5)
+}
+
+// imageTriangles are triangles that are filled onto an ImageCanvas.
+type imageTriangles struct {
+	*pixel.TrianglesData
+	dst *ImageCanvas
+}
+
+func (t *imageTriangles) Draw() {
+	t.dst.rasterize(t.TrianglesData, nil)
+}
+
+// imagePicture is a picture that is sampled when filling
+// triangles onto an ImageCanvas.
+type imagePicture struct {
+	pixel.Picture
+	sampler pixel.PictureColor
+	dst     *ImageCanvas
+}
+
+func (p *imagePicture) Draw(t pixel.TargetTriangles) {
+	tri, ok := t.(*imageTriangles)
+	if !ok || tri.dst != p.dst {
+		panic(fmt.Errorf("(%T).Draw: TargetTriangles generated by different Target", p))
+	}
+	p.dst.rasterize(tri.TrianglesData, p.sampler)
+}
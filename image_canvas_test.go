@@ -0,0 +1,135 @@
+package wo
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	opaqueRed   = color.RGBA{R: 0xff, A: 0xff}
+	opaqueGreen = color.RGBA{G: 0xff, A: 0xff}
+	opaqueBlue  = color.RGBA{B: 0xff, A: 0xff}
+	opaqueWhite = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+func TestImageCanvas_Clear(t *testing.T) {
+	canvas := NewImageCanvas(pixel.R(0, 0, 3, 2))
+	canvas.Clear(opaqueRed)
+
+	img := canvas.Image()
+
+	assert.Equal(t, image.Rect(0, 0, 3, 2), img.Bounds())
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			assert.Equal(t, opaqueRed, img.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestImageCanvas_Color(t *testing.T) {
+	canvas := NewImageCanvas(pixel.R(10, 10, 12, 12))
+	canvas.Clear(opaqueBlue)
+
+	assert.Equal(t, pixel.ToRGBA(opaqueBlue), canvas.Color(pixel.V(10, 10)))
+	assert.Equal(t, pixel.ToRGBA(opaqueBlue), canvas.Color(pixel.V(11.5, 11.5)))
+	assert.Equal(t, pixel.Alpha(0), canvas.Color(pixel.V(9, 10)))
+	assert.Equal(t, pixel.Alpha(0), canvas.Color(pixel.V(12, 10)))
+}
+
+func TestImageCanvas_triangles(t *testing.T) {
+	canvas := NewImageCanvas(pixel.R(0, 0, 4, 4))
+
+	im := imdraw.New(nil)
+	im.Color = opaqueGreen
+	im.Push(pixel.V(0, 0), pixel.V(4, 2))
+	im.Rectangle(0)
+	im.Draw(canvas)
+
+	img := canvas.Image()
+
+	// the bottom half of the canvas is the bottom half of the image
+	for x := 0; x < 4; x++ {
+		assert.Equal(t, color.RGBA{}, img.RGBAAt(x, 0))
+		assert.Equal(t, color.RGBA{}, img.RGBAAt(x, 1))
+		assert.Equal(t, opaqueGreen, img.RGBAAt(x, 2))
+		assert.Equal(t, opaqueGreen, img.RGBAAt(x, 3))
+	}
+}
+
+func TestImageCanvas_sharedEdgesBlendOnce(t *testing.T) {
+	canvas := NewImageCanvas(pixel.R(0, 0, 8, 8))
+
+	im := imdraw.New(nil)
+	im.Color = pixel.Alpha(0.5)
+	im.Push(pixel.V(0, 0), pixel.V(8, 8))
+	im.Rectangle(0)
+	im.Draw(canvas)
+
+	img := canvas.Image()
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			assert.Equal(t, uint8(0x80), img.RGBAAt(x, y).A, "pixel %d,%d", x, y)
+		}
+	}
+}
+
+func TestImageCanvas_matrixAndColorMask(t *testing.T) {
+	canvas := NewImageCanvas(pixel.R(0, 0, 4, 4))
+	canvas.SetMatrix(pixel.IM.Moved(pixel.V(2, 2)))
+	canvas.SetColorMask(opaqueRed)
+
+	im := imdraw.New(nil)
+	im.Color = opaqueWhite
+	im.Push(pixel.V(0, 0), pixel.V(2, 2))
+	im.Rectangle(0)
+	im.Draw(canvas)
+
+	img := canvas.Image()
+
+	assert.Equal(t, opaqueRed, img.RGBAAt(2, 0))
+	assert.Equal(t, opaqueRed, img.RGBAAt(3, 1))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(0, 2))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(1, 1))
+}
+
+func TestImageCanvas_sprite(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.SetRGBA(0, 0, opaqueRed)
+	src.SetRGBA(1, 0, opaqueGreen)
+	src.SetRGBA(0, 1, opaqueBlue)
+	src.SetRGBA(1, 1, opaqueWhite)
+	pic := pixel.PictureDataFromImage(src)
+
+	canvas := NewImageCanvas(pixel.R(0, 0, 4, 4))
+	sprite := pixel.NewSprite(pic, pic.Bounds())
+	sprite.Draw(canvas, pixel.IM.Scaled(pixel.ZV, 2).Moved(canvas.Bounds().Center()))
+
+	img := canvas.Image()
+
+	assert.Equal(t, opaqueRed, img.RGBAAt(0, 0))
+	assert.Equal(t, opaqueRed, img.RGBAAt(1, 1))
+	assert.Equal(t, opaqueGreen, img.RGBAAt(3, 0))
+	assert.Equal(t, opaqueBlue, img.RGBAAt(0, 3))
+	assert.Equal(t, opaqueWhite, img.RGBAAt(3, 3))
+}
+
+func TestImageCanvas_Draw(t *testing.T) {
+	src := NewImageCanvas(pixel.R(0, 0, 2, 2))
+	src.Clear(opaqueBlue)
+
+	dst := NewImageCanvas(pixel.R(0, 0, 4, 4))
+	src.Draw(dst, pixel.IM.Moved(pixel.V(3, 3)))
+
+	img := dst.Image()
+
+	assert.Equal(t, opaqueBlue, img.RGBAAt(2, 0))
+	assert.Equal(t, opaqueBlue, img.RGBAAt(3, 1))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(1, 1))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(2, 2))
+}
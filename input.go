@@ -22,6 +22,7 @@ type Input interface {
 	Repeated(button ...pixelgl.Button) bool
 
 	// MousePosition returns the current mouse position in the Window's Bounds.
+	// The Input of a World reports it on the World's canvas instead.
 	MousePosition() pixel.Vec
 
 	// MouseScroll returns the mouse scroll amount (in both axes) since the last call to Window.Update.
@@ -82,3 +83,217 @@ func (w *windowInput) MouseScroll() pixel.Vec {
 func (w *windowInput) Typed() string {
 	return w.win.Typed()
 }
+
+// WindowMousePosition returns the mouse position of an Input in the
+// Window's Bounds, even if the Input reports it on a World's canvas.
+func WindowMousePosition(input Input) pixel.Vec {
+	if window, ok := input.(windowMouseInput); ok {
+		return window.WindowMousePosition()
+	}
+	return input.MousePosition()
+}
+
+// WorldMousePosition returns the mouse position of an Input in world
+// coordinates, for Scenes that draw onto the canvas with a camera Matrix
+// that moves world coordinates onto the canvas.
+func WorldMousePosition(input Input, camera pixel.Matrix) pixel.Vec {
+	return camera.Unproject(input.MousePosition())
+}
+
+// windowMouseInput is an Input that can report the
+// mouse position in the Window's Bounds.
+type windowMouseInput interface {
+	WindowMousePosition() pixel.Vec
+}
+
+// canvasInput is an Input that reports the mouse position on the
+// canvas of a World instead of on its window. It also reads the
+// gamepads of its source, if it has any.
+type canvasInput struct {
+	Input
+	GamepadInput
+
+	// fit is the Matrix the canvas is drawn onto the window with
+	fit    pixel.Matrix
+	canvas pixel.Rect
+}
+
+func newCanvasInput(source Input) *canvasInput {
+	c := &canvasInput{fit: pixel.IM}
+	c.setSource(source)
+	return c
+}
+
+// setSource replaces the Input the canvasInput reads from.
+func (c *canvasInput) setSource(source Input) {
+	c.Input = source
+	c.GamepadInput = InputGamepads(source)
+}
+
+// setFit sets the Matrix that draws the canvas,
+// centered at the origin, onto the window.
+func (c *canvasInput) setFit(fit pixel.Matrix, canvas pixel.Rect) {
+	c.fit = fit
+	c.canvas = canvas
+}
+
+// MousePosition returns the mouse position on the canvas. The
+// position is outside of the canvas Bounds if the mouse is outside
+// of the part of the window the canvas is shown in.
+func (c *canvasInput) MousePosition() pixel.Vec {
+	return c.fit.Unproject(c.Input.MousePosition()).Add(c.canvas.Center())
+}
+
+func (c *canvasInput) WindowMousePosition() pixel.Vec {
+	return c.Input.MousePosition()
+}
+
+// latchedInput is an Input that remembers the single-frame events of
+// its underlying Input, such as JustPressed, until they are consumed.
+//
+// It allows a World using a fixed time step to run zero Updates in
+// one frame without missing events and several Updates in another
+// frame without repeating them.
+type latchedInput struct {
+	Input
+
+	justPressed  [pixelgl.KeyLast + 1]bool
+	justReleased [pixelgl.KeyLast + 1]bool
+	repeated     [pixelgl.KeyLast + 1]bool
+	scroll       pixel.Vec
+	typed        string
+
+	joystickPressed  map[GamepadButton]bool
+	joystickReleased map[GamepadButton]bool
+	connected        [JoystickLast + 1]bool
+	disconnected     [JoystickLast + 1]bool
+}
+
+// latch records the single-frame events of the underlying
+// Input. It should be called once every frame.
+func (l *latchedInput) latch() {
+	for b := pixelgl.Button(0); b <= pixelgl.KeyLast; b++ {
+		l.justPressed[b] = l.justPressed[b] || l.Input.JustPressed(b)
+		l.justReleased[b] = l.justReleased[b] || l.Input.JustReleased(b)
+		l.repeated[b] = l.repeated[b] || l.Input.Repeated(b)
+	}
+	l.scroll = l.scroll.Add(l.Input.MouseScroll())
+	l.typed += l.Input.Typed()
+	l.latchGamepads()
+}
+
+// latchGamepads records the single-frame events of the
+// gamepads of the underlying Input, if it has any.
+func (l *latchedInput) latchGamepads() {
+	gamepads, ok := l.Input.(GamepadInput)
+	if !ok {
+		return
+	}
+	for js := Joystick1; js <= JoystickLast; js++ {
+		l.connected[js] = l.connected[js] || gamepads.JoystickJustConnected(js)
+		l.disconnected[js] = l.disconnected[js] || gamepads.JoystickJustDisconnected(js)
+		for b := 0; b < gamepads.JoystickButtonCount(js); b++ {
+			button := GamepadButton{Joystick: js, Button: b}
+			if gamepads.JoystickJustPressed(js, b) {
+				if l.joystickPressed == nil {
+					l.joystickPressed = make(map[GamepadButton]bool)
+				}
+				l.joystickPressed[button] = true
+			}
+			if gamepads.JoystickJustReleased(js, b) {
+				if l.joystickReleased == nil {
+					l.joystickReleased = make(map[GamepadButton]bool)
+				}
+				l.joystickReleased[button] = true
+			}
+		}
+	}
+}
+
+// consume forgets all recorded events. It should be
+// called after the events were seen by an Update.
+func (l *latchedInput) consume() {
+	l.justPressed = [pixelgl.KeyLast + 1]bool{}
+	l.justReleased = [pixelgl.KeyLast + 1]bool{}
+	l.repeated = [pixelgl.KeyLast + 1]bool{}
+	l.scroll = pixel.ZV
+	l.typed = ""
+	l.joystickPressed = nil
+	l.joystickReleased = nil
+	l.connected = [JoystickLast + 1]bool{}
+	l.disconnected = [JoystickLast + 1]bool{}
+}
+
+func (l *latchedInput) JustPressed(button ...pixelgl.Button) bool {
+	return anyLatched(&l.justPressed, button)
+}
+
+func (l *latchedInput) JustReleased(button ...pixelgl.Button) bool {
+	return anyLatched(&l.justReleased, button)
+}
+
+func (l *latchedInput) Repeated(button ...pixelgl.Button) bool {
+	return anyLatched(&l.repeated, button)
+}
+
+func (l *latchedInput) MouseScroll() pixel.Vec {
+	return l.scroll
+}
+
+func (l *latchedInput) Typed() string {
+	return l.typed
+}
+
+func (l *latchedInput) WindowMousePosition() pixel.Vec {
+	return WindowMousePosition(l.Input)
+}
+
+func (l *latchedInput) JoystickPresent(js Joystick) bool {
+	return InputGamepads(l.Input).JoystickPresent(js)
+}
+
+func (l *latchedInput) JoystickJustConnected(js Joystick) bool {
+	return js >= 0 && js <= JoystickLast && l.connected[js]
+}
+
+func (l *latchedInput) JoystickJustDisconnected(js Joystick) bool {
+	return js >= 0 && js <= JoystickLast && l.disconnected[js]
+}
+
+func (l *latchedInput) JoystickName(js Joystick) string {
+	return InputGamepads(l.Input).JoystickName(js)
+}
+
+func (l *latchedInput) JoystickButtonCount(js Joystick) int {
+	return InputGamepads(l.Input).JoystickButtonCount(js)
+}
+
+func (l *latchedInput) JoystickAxisCount(js Joystick) int {
+	return InputGamepads(l.Input).JoystickAxisCount(js)
+}
+
+func (l *latchedInput) JoystickPressed(js Joystick, button int) bool {
+	return InputGamepads(l.Input).JoystickPressed(js, button)
+}
+
+func (l *latchedInput) JoystickJustPressed(js Joystick, button int) bool {
+	return l.joystickPressed[GamepadButton{Joystick: js, Button: button}]
+}
+
+func (l *latchedInput) JoystickJustReleased(js Joystick, button int) bool {
+	return l.joystickReleased[GamepadButton{Joystick: js, Button: button}]
+}
+
+func (l *latchedInput) JoystickAxis(js Joystick, axis int) float64 {
+	return InputGamepads(l.Input).JoystickAxis(js, axis)
+}
+
+// anyLatched returns whether any button was latched.
+func anyLatched(latched *[pixelgl.KeyLast + 1]bool, buttons []pixelgl.Button) bool {
+	for _, b := range buttons {
+		if b >= 0 && b <= pixelgl.KeyLast && latched[b] {
+			return true
+		}
+	}
+	return false
+}
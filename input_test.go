@@ -0,0 +1,131 @@
+package wo
+
+import (
+	"image"
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Input = &windowInput{}
+	_ Input = &latchedInput{}
+	_ Input = &canvasInput{}
+)
+
+// mouseScene records the mouse position of every Update.
+type mouseScene struct {
+	*testScene
+	positions []pixel.Vec
+}
+
+func (s *mouseScene) Update(dt float64, input Input) SceneResult {
+	s.positions = append(s.positions, input.MousePosition())
+	return s.testScene.Update(dt, input)
+}
+
+func TestLatchedInput_keepsEventsUntilConsumed(t *testing.T) {
+	input := newTestInput()
+	latched := &latchedInput{Input: input}
+
+	input.press(pixelgl.KeySpace)
+	input.scroll = pixel.V(0, 1)
+	input.typed = "a"
+	latched.latch()
+	input.nextFrame()
+	input.scroll = pixel.V(0, 2)
+	input.typed = "b"
+	latched.latch()
+
+	assert.True(t, latched.JustPressed(pixelgl.KeySpace))
+	assert.True(t, latched.Pressed(pixelgl.KeySpace))
+	assert.False(t, latched.JustReleased(pixelgl.KeySpace))
+	assert.Equal(t, pixel.V(0, 3), latched.MouseScroll())
+	assert.Equal(t, "ab", latched.Typed())
+}
+
+func TestLatchedInput_consume(t *testing.T) {
+	input := newTestInput()
+	latched := &latchedInput{Input: input}
+
+	input.press(pixelgl.KeySpace)
+	input.repeated[pixelgl.KeyA] = true
+	input.typed = "a"
+	latched.latch()
+	latched.consume()
+
+	assert.False(t, latched.JustPressed(pixelgl.KeySpace))
+	assert.False(t, latched.Repeated(pixelgl.KeyA))
+	assert.True(t, latched.Pressed(pixelgl.KeySpace))
+	assert.Equal(t, "", latched.Typed())
+}
+
+func TestLatchedInput_release(t *testing.T) {
+	input := newTestInput()
+	latched := &latchedInput{Input: input}
+
+	input.release(pixelgl.MouseButtonLeft)
+	latched.latch()
+
+	assert.True(t, latched.JustReleased(pixelgl.KeyA, pixelgl.MouseButtonLeft))
+}
+
+func TestCanvasInput_MousePosition(t *testing.T) {
+	source := newTestInput()
+	input := newCanvasInput(source)
+	canvas := pixel.R(0, 0, 100, 50)
+	input.setFit(FitMatrix(FitLetterbox, canvas, pixel.R(0, 0, 200, 200)), canvas)
+
+	source.mouse = pixel.V(100, 100)
+	assert.Equal(t, pixel.V(50, 25), input.MousePosition())
+	source.mouse = pixel.V(0, 50)
+	assert.Equal(t, pixel.V(0, 0), input.MousePosition())
+	assert.Equal(t, pixel.V(0, 50), input.WindowMousePosition())
+}
+
+func TestWorld_Input_mouseFollowsResize(t *testing.T) {
+	source := newTestInput()
+	source.mouse = pixel.V(1, 1)
+	scene := &mouseScene{testScene: newTestScene("scene")}
+	window := NewHeadlessWindow(2, 2, source)
+	window.SetMaxFrames(2)
+	window.OnFrame = func(frame int, img *image.RGBA) {
+		if frame == 1 {
+			window.SetSize(6, 4)
+			source.mouse = pixel.V(1, 0)
+		}
+	}
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFitMode(FitLetterbox)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []pixel.Vec{pixel.V(1, 1), pixel.V(0, 0)}, scene.positions)
+	assert.Equal(t, pixel.V(1, 0), WindowMousePosition(world.Input()))
+}
+
+func TestWindowMousePosition(t *testing.T) {
+	source := newTestInput()
+	source.mouse = pixel.V(4, 4)
+	input := newCanvasInput(source)
+	input.setFit(pixel.IM.Scaled(pixel.ZV, 2), pixel.R(-1, -1, 1, 1))
+
+	assert.Equal(t, pixel.V(4, 4), WindowMousePosition(source))
+	assert.Equal(t, pixel.V(4, 4), WindowMousePosition(&latchedInput{Input: input}))
+	assert.Equal(t, pixel.V(2, 2), (&latchedInput{Input: input}).MousePosition())
+}
+
+func TestWorldMousePosition(t *testing.T) {
+	input := newTestInput()
+	input.mouse = pixel.V(30, 20)
+	camera := pixel.IM.Moved(pixel.V(-100, 0)).Scaled(pixel.ZV, 2)
+
+	assert.Equal(t, pixel.V(115, 10), WorldMousePosition(input, camera))
+}
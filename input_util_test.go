@@ -0,0 +1,82 @@
+package wo
+
+import (
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// testInput is an Input whose state is set directly by tests.
+type testInput struct {
+	pressed      map[pixelgl.Button]bool
+	justPressed  map[pixelgl.Button]bool
+	justReleased map[pixelgl.Button]bool
+	repeated     map[pixelgl.Button]bool
+	mouse        pixel.Vec
+	scroll       pixel.Vec
+	typed        string
+}
+
+func newTestInput() *testInput {
+	return &testInput{
+		pressed:      make(map[pixelgl.Button]bool),
+		justPressed:  make(map[pixelgl.Button]bool),
+		justReleased: make(map[pixelgl.Button]bool),
+		repeated:     make(map[pixelgl.Button]bool),
+	}
+}
+
+// nextFrame clears all single-frame events.
+func (i *testInput) nextFrame() {
+	i.justPressed = make(map[pixelgl.Button]bool)
+	i.justReleased = make(map[pixelgl.Button]bool)
+	i.repeated = make(map[pixelgl.Button]bool)
+	i.scroll = pixel.ZV
+	i.typed = ""
+}
+
+func (i *testInput) press(b pixelgl.Button) {
+	i.pressed[b] = true
+	i.justPressed[b] = true
+}
+
+func (i *testInput) release(b pixelgl.Button) {
+	i.pressed[b] = false
+	i.justReleased[b] = true
+}
+
+func (i *testInput) Pressed(button ...pixelgl.Button) bool {
+	return anyButton(i.pressed, button)
+}
+
+func (i *testInput) JustPressed(button ...pixelgl.Button) bool {
+	return anyButton(i.justPressed, button)
+}
+
+func (i *testInput) JustReleased(button ...pixelgl.Button) bool {
+	return anyButton(i.justReleased, button)
+}
+
+func (i *testInput) Repeated(button ...pixelgl.Button) bool {
+	return anyButton(i.repeated, button)
+}
+
+func (i *testInput) MousePosition() pixel.Vec {
+	return i.mouse
+}
+
+func (i *testInput) MouseScroll() pixel.Vec {
+	return i.scroll
+}
+
+func (i *testInput) Typed() string {
+	return i.typed
+}
+
+func anyButton(state map[pixelgl.Button]bool, buttons []pixelgl.Button) bool {
+	for _, b := range buttons {
+		if state[b] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,11 @@
 package wo
 
-import "io"
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
 
 // ByteReader is a function that gets bytes by name.
 type ByteReader func(name string) ([]byte, error)
@@ -8,6 +13,37 @@ type ByteReader func(name string) ([]byte, error)
 // AssetReader is a function that get an Reader by name.
 type AssetReader func(name string) (io.Reader, error)
 
+// NewDirReader creates an AssetReader that opens files in a directory.
+// Names are slash-separated paths relative to the directory, such as
+// "img/tanks.png", which are the names go-bindata gives the same files
+// when the directory is its prefix.
+func NewDirReader(dir string) AssetReader {
+	return func(name string) (io.Reader, error) {
+		f, err := os.Open(dirPath(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+}
+
+// dirModTime returns a function that gets the modification
+// time of a file in a directory by the name of its asset.
+func dirModTime(dir string) func(name string) (time.Time, error) {
+	return func(name string) (time.Time, error) {
+		info, err := os.Stat(dirPath(dir, name))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return info.ModTime(), nil
+	}
+}
+
+// dirPath returns the path of a file in a directory by the name of its asset.
+func dirPath(dir, name string) string {
+	return filepath.Join(dir, filepath.FromSlash(name))
+}
+
 // readCloserWrapper wraps a Reader to support Close. A
 // call to Close will be propagated to the wrapped value
 // if it is supported.
@@ -1,6 +1,9 @@
 package wo
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +48,24 @@ func TestReadCloserWrapper_Close_Reader(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestNewDirReader(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "img"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "img", "a.txt"), []byte("hello"), 0644))
+	reader := NewDirReader(dir)
+
+	r, err := reader("img/a.txt")
+	if assert.Nil(t, err) {
+		wrapped := &readCloserWrapper{r}
+		b, err := ioutil.ReadAll(wrapped)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", string(b))
+		assert.Nil(t, wrapped.Close())
+	}
+
+	r, err = reader("img/missing.txt")
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, r)
+}
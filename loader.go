@@ -11,8 +11,11 @@ import (
 	"golang.org/x/image/font"
 )
 
-// Loader is a utility for loading and caching assets such as
-// Sounds, Sprites, SpriteSheets, Fonts, and FontFaces.
+// Loader is a utility for loading assets such as Sounds,
+// Sprites, SpriteSheets, Fonts, and FontFaces.
+//
+// Loaders created with NewLoader decode assets every time they are
+// loaded; see NewCachingLoader for a Loader that shares them.
 type Loader interface {
 	// Sprite loads a Sprite by name and applies any custom transformations to the image.
 	Sprite(name string, transforms ...ImageTransformer) (*pixel.Sprite, error)
@@ -96,7 +99,8 @@ func (load *simpleLoader) bytesOf(name string) ([]byte, error) {
 	return ioutil.ReadAll(r)
 }
 
-func (load *simpleLoader) Sprite(name string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+// picture decodes and transforms the image with the given name.
+func (load *simpleLoader) picture(name string, transforms []ImageTransformer) (*pixel.PictureData, error) {
 	r, err := load.readCloser(name)
 	if err != nil {
 		return nil, err
@@ -110,26 +114,23 @@ func (load *simpleLoader) Sprite(name string, transforms ...ImageTransformer) (*
 	if err != nil {
 		return nil, err
 	}
-	pic := pixel.PictureDataFromImage(img)
-	sprite := pixel.NewSprite(pic, pic.Bounds())
-	return sprite, nil
+	return pixel.PictureDataFromImage(img), nil
 }
 
-func (load *simpleLoader) SpriteSheet(name string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
-	r, err := load.readCloser(name)
-	if err != nil {
-		return nil, err
-	}
-	defer r.Close()
-	img, _, err := image.Decode(r)
+func (load *simpleLoader) Sprite(name string, transforms ...ImageTransformer) (*pixel.Sprite, error) {
+	pic, err := load.picture(name, transforms)
 	if err != nil {
 		return nil, err
 	}
-	img, err = TransformImage(img, transforms...)
+	sprite := pixel.NewSprite(pic, pic.Bounds())
+	return sprite, nil
+}
+
+func (load *simpleLoader) SpriteSheet(name string, opts SpriteSheetOptions, transforms ...ImageTransformer) (*SpriteSheet, error) {
+	pic, err := load.picture(name, transforms)
 	if err != nil {
 		return nil, err
 	}
-	pic := pixel.PictureDataFromImage(img)
 	sheet := NewSpriteSheet(pic, opts)
 	return sheet, nil
 }
@@ -159,8 +160,13 @@ func (load *simpleLoader) FontFace(name string, size float64) (font.Face, error)
 	if err != nil {
 		return nil, err
 	}
+	return newFontFace(f, size), nil
+}
+
+// newFontFace creates a FontFace of a given size for a Font.
+func newFontFace(f *truetype.Font, size float64) font.Face {
 	return truetype.NewFace(f, &truetype.Options{
 		Size:              size,
 		GlyphCacheEntries: 1,
-	}), nil
+	})
 }
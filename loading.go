@@ -0,0 +1,215 @@
+package wo
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/faiface/pixel"
+	"github.com/pkg/errors"
+)
+
+// AsyncSceneFactory creates a Scene like a SceneFactory, but runs on its
+// own goroutine so that a World can keep drawing a loading Scene while
+// assets are decoded. Progress is reported with the SceneLoad.
+//
+// Methods of the Canvas given to an AsyncSceneFactory are run on the
+// World's goroutine while it loads, as is any function passed to
+// SceneLoad.Do. Nothing else is; the factory must not call pixelgl
+// or draw onto anything but its Canvas until it has returned.
+// The Scene can keep using the Canvas once it is created.
+//
+// If the World stops waiting for the Scene, such as because its window
+// was closed, the Scene is exited and disposed once the factory returns
+// it, on the factory's goroutine rather than the World's.
+type AsyncSceneFactory func(canvas Canvas, load *SceneLoad) (Scene, error)
+
+// LoadingScene is a Scene that is shown while an AsyncSceneFactory
+// is loading and is told how far loading has progressed.
+type LoadingScene interface {
+	Scene
+
+	// LoadProgress is called before every update with the progress of
+	// the Scene being loaded, from 0 to 1.
+	LoadProgress(progress float64)
+}
+
+// SceneLoad is the connection between an AsyncSceneFactory
+// and the World it is loading a Scene for.
+type SceneLoad struct {
+	mu       sync.Mutex
+	progress float64
+
+	// calls are functions to run on the World's goroutine
+	calls chan func()
+	// done is closed once the factory has returned
+	done chan struct{}
+	// abandoned is closed once the World no longer waits for the factory
+	abandoned chan struct{}
+
+	scene Scene
+	err   error
+}
+
+func newSceneLoad() *SceneLoad {
+	return &SceneLoad{
+		calls:     make(chan func()),
+		done:      make(chan struct{}),
+		abandoned: make(chan struct{}),
+	}
+}
+
+// SetProgress reports how far loading has progressed, from 0 to 1.
+func (l *SceneLoad) SetProgress(progress float64) {
+	if progress < 0 {
+		progress = 0
+	} else if progress > 1 {
+		progress = 1
+	}
+	l.mu.Lock()
+	l.progress = progress
+	l.mu.Unlock()
+}
+
+// Progress returns how far loading has progressed, from 0 to 1.
+func (l *SceneLoad) Progress() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.progress
+}
+
+// Do runs f on the World's goroutine between frames and
+// waits for it to finish. Once the factory has returned,
+// f is run right away, because the Scene it created is
+// only updated and drawn on the World's goroutine.
+//
+// f is not run if the World stops waiting for the Scene,
+// for example because its window was closed.
+func (l *SceneLoad) Do(f func()) {
+	select {
+	case <-l.done:
+		f()
+		return
+	default:
+	}
+	ran := make(chan struct{})
+	call := func() {
+		defer close(ran)
+		f()
+	}
+	select {
+	case l.calls <- call:
+	case <-l.abandoned:
+		return
+	}
+	select {
+	case <-ran:
+	case <-l.abandoned:
+	}
+}
+
+// start runs the factory on a new goroutine.
+func (l *SceneLoad) start(factory AsyncSceneFactory, canvas Canvas) {
+	go func() {
+		defer close(l.done)
+		defer func() {
+			if r := recover(); r != nil {
+				l.err = errors.Errorf("panic loading scene: %v", r)
+			}
+		}()
+		l.scene, l.err = factory(&loadCanvas{canvas: canvas, load: l}, l)
+		if l.err == nil {
+			l.SetProgress(1)
+		}
+	}()
+}
+
+// poll runs pending calls and returns whether the factory has returned.
+func (l *SceneLoad) poll() bool {
+	for {
+		select {
+		case call := <-l.calls:
+			call()
+		case <-l.done:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// wait runs calls until the factory has returned.
+func (l *SceneLoad) wait() {
+	for {
+		select {
+		case call := <-l.calls:
+			call()
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// abandon stops waiting for the factory. Calls it makes
+// to Do return immediately, and the Scene it still creates
+// is exited and disposed on a goroutine of its own, so that
+// the assets it loaded are released.
+func (l *SceneLoad) abandon() {
+	close(l.abandoned)
+	go func() {
+		<-l.done
+		if l.err == nil && l.scene != nil {
+			exitScene(l.scene)
+		}
+	}()
+}
+
+// result returns the Scene created by the factory once it is done.
+func (l *SceneLoad) result() (Scene, error) {
+	return l.scene, l.err
+}
+
+// loadCanvas is a Canvas that runs every method of
+// another Canvas on the World's goroutine.
+type loadCanvas struct {
+	canvas Canvas
+	load   *SceneLoad
+}
+
+func (c *loadCanvas) Bounds() (bounds pixel.Rect) {
+	c.load.Do(func() { bounds = c.canvas.Bounds() })
+	return bounds
+}
+
+func (c *loadCanvas) Clear(col color.Color) {
+	c.load.Do(func() { c.canvas.Clear(col) })
+}
+
+func (c *loadCanvas) Draw(t pixel.Target, matrix pixel.Matrix) {
+	c.load.Do(func() { c.canvas.Draw(t, matrix) })
+}
+
+func (c *loadCanvas) SetMatrix(m pixel.Matrix) {
+	c.load.Do(func() { c.canvas.SetMatrix(m) })
+}
+
+func (c *loadCanvas) SetColorMask(mask color.Color) {
+	c.load.Do(func() { c.canvas.SetColorMask(mask) })
+}
+
+func (c *loadCanvas) MakeTriangles(t pixel.Triangles) (tri pixel.TargetTriangles) {
+	c.load.Do(func() { tri = c.canvas.MakeTriangles(t) })
+	return tri
+}
+
+func (c *loadCanvas) MakePicture(p pixel.Picture) (pic pixel.TargetPicture) {
+	c.load.Do(func() { pic = c.canvas.MakePicture(p) })
+	return pic
+}
+
+// loadProgressScene tells a Scene how far loading
+// has progressed if it is a LoadingScene.
+func loadProgressScene(scene Scene, progress float64) {
+	if loading, ok := scene.(LoadingScene); ok {
+		loading.LoadProgress(progress)
+	}
+}
@@ -0,0 +1,327 @@
+package wo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/stretchr/testify/assert"
+)
+
+// progressScene is a testScene that implements LoadingScene.
+type progressScene struct {
+	*testScene
+	progress []float64
+	// onUpdate is called with the number of updates after every update
+	onUpdate func(updates int)
+}
+
+func (s *progressScene) LoadProgress(progress float64) {
+	s.progress = append(s.progress, progress)
+}
+
+func (s *progressScene) Update(dt float64, input Input) SceneResult {
+	result := s.testScene.Update(dt, input)
+	if s.onUpdate != nil {
+		s.onUpdate(s.updates)
+	}
+	return result
+}
+
+func TestSceneLoad_SetProgress(t *testing.T) {
+	load := newSceneLoad()
+
+	assert.Equal(t, 0.0, load.Progress())
+	load.SetProgress(0.25)
+	assert.Equal(t, 0.25, load.Progress())
+	load.SetProgress(2)
+	assert.Equal(t, 1.0, load.Progress())
+	load.SetProgress(-1)
+	assert.Equal(t, 0.0, load.Progress())
+}
+
+func TestSceneLoad_Do(t *testing.T) {
+	scene := newTestScene("scene")
+	ran := false
+	load := newSceneLoad()
+	load.start(func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.Do(func() {
+			ran = true
+		})
+		return scene, nil
+	}, NewImageCanvas(pixel.R(0, 0, 1, 1)))
+
+	load.wait()
+	result, err := load.result()
+
+	assert.True(t, ran)
+	assert.NoError(t, err)
+	assert.Equal(t, scene, result)
+	assert.Equal(t, 1.0, load.Progress())
+}
+
+func TestSceneLoad_canvas(t *testing.T) {
+	var bounds pixel.Rect
+	load := newSceneLoad()
+	load.start(func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		bounds = canvas.Bounds()
+		return newTestScene("scene"), nil
+	}, NewImageCanvas(pixel.R(0, 0, 3, 4)))
+
+	load.wait()
+
+	assert.Equal(t, pixel.R(0, 0, 3, 4), bounds)
+}
+
+func TestSceneLoad_abandon(t *testing.T) {
+	load := newSceneLoad()
+	load.abandon()
+	ran := false
+	load.start(func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.Do(func() {
+			ran = true
+		})
+		return nil, nil
+	}, NewImageCanvas(pixel.R(0, 0, 1, 1)))
+
+	<-load.done
+
+	assert.False(t, ran)
+}
+
+func TestSceneLoad_error(t *testing.T) {
+	load := newSceneLoad()
+	load.start(func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.SetProgress(0.5)
+		return nil, errors.New("no assets")
+	}, NewImageCanvas(pixel.R(0, 0, 1, 1)))
+
+	load.wait()
+	_, err := load.result()
+
+	assert.EqualError(t, err, "no assets")
+	assert.Equal(t, 0.5, load.Progress())
+}
+
+func TestSceneLoad_panic(t *testing.T) {
+	load := newSceneLoad()
+	load.start(func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		panic("corrupt image")
+	}, NewImageCanvas(pixel.R(0, 0, 1, 1)))
+
+	load.wait()
+	_, err := load.result()
+
+	assert.EqualError(t, err, "panic loading scene: corrupt image")
+}
+
+func TestWorld_AddAsyncScene(t *testing.T) {
+	release := make(chan struct{})
+	loading := &progressScene{testScene: newTestScene("loading")}
+	loading.onUpdate = func(updates int) {
+		if updates == 3 {
+			close(release)
+		}
+	}
+	game := newTestScene("game", SceneResultNone, testResultA)
+
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"loading": func(canvas Canvas) (Scene, error) {
+			return loading, nil
+		},
+	})
+	world.SetLoadingScene("loading")
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.SetProgress(0.5)
+		<-release
+		return game, nil
+	})
+
+	result, err := world.RunScene("game")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testResultA, result)
+	assert.True(t, loading.updates >= 3)
+	assert.Equal(t, loading.updates, loading.draws)
+	assert.Len(t, loading.progress, loading.updates)
+	assert.Equal(t, 2, game.updates)
+	assert.Equal(t, 1, game.draws)
+}
+
+func TestWorld_AddAsyncScene_loadingResult(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	loading := newTestScene("loading", SceneResultNone, testResultB)
+
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"loading": func(canvas Canvas) (Scene, error) {
+			return loading, nil
+		},
+	})
+	world.SetLoadingScene("loading")
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.Do(func() {})
+		<-release
+		return newTestScene("game"), nil
+	})
+
+	result, err := world.RunScene("game")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testResultB, result)
+	assert.Equal(t, 2, loading.updates)
+}
+
+func TestWorld_AddAsyncScene_noLoadingScene(t *testing.T) {
+	game := newTestScene("game", testResultA)
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, nil)
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		return game, nil
+	})
+
+	result, err := world.RunScene("game")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testResultA, result)
+}
+
+func TestWorld_AddAsyncScene_error(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, nil)
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		return nil, errors.New("no assets")
+	})
+
+	result, err := world.RunScene("game")
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "unable to create scene game: no assets")
+}
+
+func TestWorld_AddAsyncScene_windowClosed(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(3)
+	world := NewHeadlessWorld(window, nil)
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		<-release
+		return newTestScene("game"), nil
+	})
+
+	result, err := world.RunScene("game")
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultWindowClosed, result)
+	assert.Equal(t, 3, window.Frames())
+}
+
+// disposeScene is a testScene that reports when it is disposed.
+type disposeScene struct {
+	*testScene
+	disposed chan struct{}
+}
+
+func (s *disposeScene) Dispose() {
+	close(s.disposed)
+}
+
+func TestWorld_AddAsyncScene_windowClosedDisposes(t *testing.T) {
+	release := make(chan struct{})
+	scene := &disposeScene{testScene: newTestScene("game"), disposed: make(chan struct{})}
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(3)
+	world := NewHeadlessWorld(window, nil)
+	world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		<-release
+		return scene, nil
+	})
+
+	result, err := world.RunScene("game")
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultWindowClosed, result)
+
+	// the factory returns after the window has closed
+	close(release)
+	select {
+	case <-scene.disposed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the abandoned scene was not disposed")
+	}
+}
+
+func TestWorld_PushScene_async(t *testing.T) {
+	overlay := newTestOverlay("overlay", true, false)
+	base := newTestScene("base")
+	window := NewHeadlessWindow(1, 1, nil)
+	world := NewHeadlessWorld(window, nil)
+	world.AddAsyncScene("overlay", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+		load.Do(func() {})
+		return overlay, nil
+	})
+	world.stack.push("base", base)
+
+	err := world.PushScene("overlay")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, world.stack.len())
+	assert.Equal(t, overlay, world.stack.top().scene)
+}
+
+// canvasScene is a Scene that keeps the Canvas its factory was given.
+type canvasScene struct {
+	*testScene
+	canvas Canvas
+	bounds []pixel.Rect
+}
+
+func (s *canvasScene) Update(dt float64, input Input) SceneResult {
+	s.bounds = append(s.bounds, s.canvas.Bounds())
+	return s.testScene.Update(dt, input)
+}
+
+func (s *canvasScene) Draw(canvas Canvas) {
+	s.testScene.Draw(canvas)
+	s.canvas.Clear(opaqueRed)
+}
+
+func TestWorld_AddAsyncScene_sceneUsesCanvas(t *testing.T) {
+	for _, loadingScene := range []bool{true, false} {
+		var game *canvasScene
+		window := NewHeadlessWindow(2, 2, nil)
+		world := NewHeadlessWorld(window, map[string]SceneFactory{
+			"loading": func(canvas Canvas) (Scene, error) {
+				return newTestScene("loading"), nil
+			},
+		})
+		if loadingScene {
+			world.SetLoadingScene("loading")
+		}
+		world.AddAsyncScene("game", func(canvas Canvas, load *SceneLoad) (Scene, error) {
+			game = &canvasScene{testScene: newTestScene("game", SceneResultNone, testResultA), canvas: canvas}
+			return game, nil
+		})
+
+		done := make(chan struct{})
+		var result SceneResult
+		var err error
+		go func() {
+			defer close(done)
+			result, err = world.RunScene("game")
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("scene deadlocked using its canvas")
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testResultA, result)
+		assert.Equal(t, []pixel.Rect{pixel.R(0, 0, 2, 2), pixel.R(0, 0, 2, 2)}, game.bounds)
+		assert.Equal(t, 1, game.draws)
+	}
+}
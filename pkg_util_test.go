@@ -1,12 +1,50 @@
 package wo
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 )
 
 func funcName(t *testing.T, f interface{}) string {
 	t.Helper()
 	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
 }
+
+// newTestDir creates a temporary directory and a function that removes it.
+func newTestDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "wo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// writeTestFile writes a file in a directory by the name of its asset and
+// moves its modification time forward, so that it is seen as changed
+// even when it is written several times within a second.
+func writeTestFile(t *testing.T, dir, name string, b []byte) {
+	t.Helper()
+	path := dirPath(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	testFileWrites++
+	modTime := time.Now().Add(time.Duration(testFileWrites) * time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testFileWrites is the number of files written with writeTestFile.
+var testFileWrites int
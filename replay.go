@@ -0,0 +1,454 @@
+package wo
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/pkg/errors"
+)
+
+// replayVersion is the version of the replay format
+const replayVersion = 1
+
+// button states recorded in a replay
+const (
+	replayPressed = 1 << iota
+	replayJustPressed
+	replayJustReleased
+	replayRepeated
+)
+
+// joystick states recorded in a replay
+const (
+	replayConnected = 1 << iota
+	replayJustConnected
+	replayJustDisconnected
+)
+
+// replayHeader is the start of a replay.
+type replayHeader struct {
+	Version int
+	Seed    int64
+}
+
+// replayFrame is the input and time delta of a single frame.
+// Only buttons that are pressed or had an event are recorded,
+// and only joysticks that are connected or were just disconnected.
+type replayFrame struct {
+	Dt        time.Duration
+	Buttons   []replayButton
+	Mouse     pixel.Vec
+	Scroll    pixel.Vec
+	Typed     string
+	Joysticks []replayJoystick
+}
+
+type replayButton struct {
+	Button pixelgl.Button
+	State  uint8
+}
+
+// replayJoystick is the state of a joystick in a single frame.
+// Its buttons have the same states as the Buttons of a frame.
+type replayJoystick struct {
+	Joystick Joystick
+	State    uint8
+	Name     string
+	Buttons  []uint8
+	Axes     []float64
+}
+
+// Recorder records the Input and time delta of every frame a World
+// runs, along with the seed of its random number generator, so that
+// the session can be replayed exactly with a Replay.
+//
+// A replay is only exact if Scenes get all of their randomness from the
+// seed and all of their time from the time delta. Scenes created by an
+// AsyncSceneFactory take a different number of frames to load each time.
+type Recorder struct {
+	gzip    *gzip.Writer
+	encoder *gob.Encoder
+	seed    int64
+	frames  int
+}
+
+// NewRecorder starts recording a replay onto w. The seed is stored
+// in the replay to seed random number generators when replaying.
+func NewRecorder(w io.Writer, seed int64) (*Recorder, error) {
+	zw := gzip.NewWriter(w)
+	r := &Recorder{
+		gzip:    zw,
+		encoder: gob.NewEncoder(zw),
+		seed:    seed,
+	}
+	if err := r.encoder.Encode(replayHeader{Version: replayVersion, Seed: seed}); err != nil {
+		return nil, errors.Wrap(err, "unable to write replay header")
+	}
+	return r, nil
+}
+
+// Seed returns the seed stored in the replay.
+func (r *Recorder) Seed() int64 {
+	return r.seed
+}
+
+// Frames returns the number of frames recorded.
+func (r *Recorder) Frames() int {
+	return r.frames
+}
+
+// Record records a frame that started dt after the previous
+// frame with the state of an Input.
+func (r *Recorder) Record(dt time.Duration, input Input) error {
+	frame := replayFrame{
+		Dt:     dt,
+		Mouse:  input.MousePosition(),
+		Scroll: input.MouseScroll(),
+		Typed:  input.Typed(),
+	}
+	for b := pixelgl.Button(0); b <= pixelgl.KeyLast; b++ {
+		var state uint8
+		if input.Pressed(b) {
+			state |= replayPressed
+		}
+		if input.JustPressed(b) {
+			state |= replayJustPressed
+		}
+		if input.JustReleased(b) {
+			state |= replayJustReleased
+		}
+		if input.Repeated(b) {
+			state |= replayRepeated
+		}
+		if state != 0 {
+			frame.Buttons = append(frame.Buttons, replayButton{Button: b, State: state})
+		}
+	}
+	frame.Joysticks = recordJoysticks(InputGamepads(input))
+	if err := r.encoder.Encode(frame); err != nil {
+		return errors.Wrapf(err, "unable to record frame %d", r.frames+1)
+	}
+	r.frames++
+	return nil
+}
+
+// recordJoysticks returns the state of the joysticks that are
+// connected or were just disconnected.
+func recordJoysticks(gamepads GamepadInput) []replayJoystick {
+	var joysticks []replayJoystick
+	for js := Joystick1; js <= JoystickLast; js++ {
+		var state uint8
+		if gamepads.JoystickPresent(js) {
+			state |= replayConnected
+		}
+		if gamepads.JoystickJustConnected(js) {
+			state |= replayJustConnected
+		}
+		if gamepads.JoystickJustDisconnected(js) {
+			state |= replayJustDisconnected
+		}
+		if state == 0 {
+			continue
+		}
+		joystick := replayJoystick{
+			Joystick: js,
+			State:    state,
+			Name:     gamepads.JoystickName(js),
+			Buttons:  make([]uint8, gamepads.JoystickButtonCount(js)),
+			Axes:     make([]float64, gamepads.JoystickAxisCount(js)),
+		}
+		for button := range joystick.Buttons {
+			if gamepads.JoystickPressed(js, button) {
+				joystick.Buttons[button] |= replayPressed
+			}
+			if gamepads.JoystickJustPressed(js, button) {
+				joystick.Buttons[button] |= replayJustPressed
+			}
+			if gamepads.JoystickJustReleased(js, button) {
+				joystick.Buttons[button] |= replayJustReleased
+			}
+		}
+		for axis := range joystick.Axes {
+			joystick.Axes[axis] = gamepads.JoystickAxis(js, axis)
+		}
+		joysticks = append(joysticks, joystick)
+	}
+	return joysticks
+}
+
+// Close finishes the replay. It does not close the
+// io.Writer the Recorder was created with.
+func (r *Recorder) Close() error {
+	return errors.Wrap(r.gzip.Close(), "unable to finish replay")
+}
+
+// Replay plays back a replay made by a Recorder. Its Input returns
+// the recorded state of the current frame and its Clock tells the
+// recorded time, so that a World replays the recorded session exactly.
+type Replay struct {
+	decoder *gob.Decoder
+	seed    int64
+	frames  int
+	err     error
+
+	input *replayInput
+	clock *replayClock
+}
+
+// NewReplay reads the start of a replay from r. Frames
+// are read from r as they are replayed.
+func NewReplay(r io.Reader) (*Replay, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read replay")
+	}
+	decoder := gob.NewDecoder(zr)
+	var header replayHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, errors.Wrap(err, "unable to read replay header")
+	}
+	if header.Version != replayVersion {
+		return nil, errors.Errorf("unsupported replay version %d", header.Version)
+	}
+	return &Replay{
+		decoder: decoder,
+		seed:    header.Seed,
+		input:   &replayInput{},
+		clock: &replayClock{
+			realTime: true,
+		},
+	}, nil
+}
+
+// Seed returns the seed that was recorded.
+func (p *Replay) Seed() int64 {
+	return p.seed
+}
+
+// Frames returns the number of frames replayed.
+func (p *Replay) Frames() int {
+	return p.frames
+}
+
+// Input returns the Input that replays the recorded input.
+func (p *Replay) Input() Input {
+	return p.input
+}
+
+// Clock returns the Clock that replays the recorded time. Only Next
+// advances its time, so that an FpsLimiter using it measures exactly
+// the recorded time delta of every frame.
+func (p *Replay) Clock() Clock {
+	return p.clock
+}
+
+// SetRealTime sets whether the Clock sleeps in real time, so that
+// a World replays at about its fps limit, or returns immediately,
+// replaying as fast as possible. It sleeps by default.
+func (p *Replay) SetRealTime(realTime bool) {
+	p.clock.mu.Lock()
+	p.clock.realTime = realTime
+	p.clock.mu.Unlock()
+}
+
+// Next moves to the next recorded frame, advancing the Clock by its
+// time delta. It returns false at the end of the replay or if the
+// replay could not be read, which is reported by Err.
+func (p *Replay) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	var frame replayFrame
+	if err := p.decoder.Decode(&frame); err != nil {
+		if err != io.EOF {
+			p.err = errors.Wrapf(err, "unable to read frame %d", p.frames+1)
+		}
+		return false
+	}
+	p.frames++
+	p.input.set(frame)
+	p.clock.advance(frame.Dt)
+	return true
+}
+
+// Err returns the error that ended the replay early, if any.
+func (p *Replay) Err() error {
+	return p.err
+}
+
+// replayInput is an Input with the state of a recorded frame,
+// including its gamepads.
+type replayInput struct {
+	buttons   [pixelgl.KeyLast + 1]uint8
+	mouse     pixel.Vec
+	scroll    pixel.Vec
+	typed     string
+	joysticks [JoystickLast + 1]*replayJoystick
+}
+
+var _ GamepadInput = &replayInput{}
+
+func (i *replayInput) set(frame replayFrame) {
+	i.buttons = [pixelgl.KeyLast + 1]uint8{}
+	for _, button := range frame.Buttons {
+		if button.Button >= 0 && button.Button <= pixelgl.KeyLast {
+			i.buttons[button.Button] = button.State
+		}
+	}
+	i.mouse = frame.Mouse
+	i.scroll = frame.Scroll
+	i.typed = frame.Typed
+	i.joysticks = [JoystickLast + 1]*replayJoystick{}
+	for index := range frame.Joysticks {
+		joystick := &frame.Joysticks[index]
+		if joystick.Joystick >= Joystick1 && joystick.Joystick <= JoystickLast {
+			i.joysticks[joystick.Joystick] = joystick
+		}
+	}
+}
+
+func (i *replayInput) any(state uint8, buttons []pixelgl.Button) bool {
+	for _, b := range buttons {
+		if b >= 0 && b <= pixelgl.KeyLast && i.buttons[b]&state != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *replayInput) Pressed(button ...pixelgl.Button) bool {
+	return i.any(replayPressed, button)
+}
+
+func (i *replayInput) JustPressed(button ...pixelgl.Button) bool {
+	return i.any(replayJustPressed, button)
+}
+
+func (i *replayInput) JustReleased(button ...pixelgl.Button) bool {
+	return i.any(replayJustReleased, button)
+}
+
+func (i *replayInput) Repeated(button ...pixelgl.Button) bool {
+	return i.any(replayRepeated, button)
+}
+
+func (i *replayInput) MousePosition() pixel.Vec {
+	return i.mouse
+}
+
+func (i *replayInput) MouseScroll() pixel.Vec {
+	return i.scroll
+}
+
+func (i *replayInput) Typed() string {
+	return i.typed
+}
+
+// joystick returns the recorded joystick if it has a state.
+func (i *replayInput) joystick(js Joystick, state uint8) *replayJoystick {
+	if js < Joystick1 || js > JoystickLast {
+		return nil
+	}
+	joystick := i.joysticks[js]
+	if joystick == nil || joystick.State&state == 0 {
+		return nil
+	}
+	return joystick
+}
+
+// joystickButton returns whether a recorded joystick button has a state.
+func (i *replayInput) joystickButton(js Joystick, button int, state uint8) bool {
+	joystick := i.joystick(js, replayConnected)
+	return joystick != nil && button >= 0 && button < len(joystick.Buttons) && joystick.Buttons[button]&state != 0
+}
+
+func (i *replayInput) JoystickPresent(js Joystick) bool {
+	return i.joystick(js, replayConnected) != nil
+}
+
+func (i *replayInput) JoystickJustConnected(js Joystick) bool {
+	return i.joystick(js, replayJustConnected) != nil
+}
+
+func (i *replayInput) JoystickJustDisconnected(js Joystick) bool {
+	return i.joystick(js, replayJustDisconnected) != nil
+}
+
+func (i *replayInput) JoystickName(js Joystick) string {
+	if joystick := i.joystick(js, replayConnected); joystick != nil {
+		return joystick.Name
+	}
+	return ""
+}
+
+func (i *replayInput) JoystickButtonCount(js Joystick) int {
+	if joystick := i.joystick(js, replayConnected); joystick != nil {
+		return len(joystick.Buttons)
+	}
+	return 0
+}
+
+func (i *replayInput) JoystickAxisCount(js Joystick) int {
+	if joystick := i.joystick(js, replayConnected); joystick != nil {
+		return len(joystick.Axes)
+	}
+	return 0
+}
+
+func (i *replayInput) JoystickPressed(js Joystick, button int) bool {
+	return i.joystickButton(js, button, replayPressed)
+}
+
+func (i *replayInput) JoystickJustPressed(js Joystick, button int) bool {
+	return i.joystickButton(js, button, replayJustPressed)
+}
+
+func (i *replayInput) JoystickJustReleased(js Joystick, button int) bool {
+	return i.joystickButton(js, button, replayJustReleased)
+}
+
+func (i *replayInput) JoystickAxis(js Joystick, axis int) float64 {
+	joystick := i.joystick(js, replayConnected)
+	if joystick == nil || axis < 0 || axis >= len(joystick.Axes) {
+		return 0
+	}
+	return joystick.Axes[axis]
+}
+
+// replayClock is a Clock whose time passes by the recorded
+// time delta of each frame. Sleeping does not advance it.
+type replayClock struct {
+	mu       sync.Mutex
+	now      time.Time
+	realTime bool
+}
+
+func (c *replayClock) advance(dt time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(dt)
+	c.mu.Unlock()
+}
+
+func (c *replayClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *replayClock) Sleep(duration time.Duration) {
+	c.mu.Lock()
+	realTime := c.realTime
+	c.mu.Unlock()
+	if realTime && duration > 0 {
+		time.Sleep(duration)
+	}
+}
+
+func (c *replayClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
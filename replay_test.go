@@ -0,0 +1,248 @@
+package wo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+// inputLogScene logs the time delta and Input of every update.
+type inputLogScene struct {
+	*testScene
+	log []string
+}
+
+func (s *inputLogScene) Update(dt float64, input Input) SceneResult {
+	s.log = append(s.log, fmt.Sprintf("dt=%v space=%v/%v/%v/%v mouse=%v scroll=%v typed=%q",
+		dt,
+		input.Pressed(pixelgl.KeySpace), input.JustPressed(pixelgl.KeySpace),
+		input.JustReleased(pixelgl.KeySpace), input.Repeated(pixelgl.KeySpace),
+		input.MousePosition(), input.MouseScroll(), input.Typed()))
+	return s.testScene.Update(dt, input)
+}
+
+func TestReplay_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, err := NewRecorder(&buf, 42)
+	assert.NoError(t, err)
+
+	input := newTestInput()
+	input.press(pixelgl.MouseButtonLeft)
+	input.repeated[pixelgl.KeyA] = true
+	input.mouse = pixel.V(1, 2)
+	input.scroll = pixel.V(0, -1)
+	input.typed = "hi"
+	assert.NoError(t, recorder.Record(16*time.Millisecond, input))
+	input.nextFrame()
+	input.release(pixelgl.MouseButtonLeft)
+	assert.NoError(t, recorder.Record(17*time.Millisecond, input))
+	assert.NoError(t, recorder.Close())
+	assert.Equal(t, 2, recorder.Frames())
+
+	replay, err := NewReplay(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), replay.Seed())
+	start := replay.Clock().Now()
+	replayed := replay.Input()
+
+	assert.True(t, replay.Next())
+	assert.True(t, replayed.Pressed(pixelgl.MouseButtonLeft))
+	assert.True(t, replayed.JustPressed(pixelgl.KeyB, pixelgl.MouseButtonLeft))
+	assert.False(t, replayed.JustReleased(pixelgl.MouseButtonLeft))
+	assert.True(t, replayed.Repeated(pixelgl.KeyA))
+	assert.Equal(t, pixel.V(1, 2), replayed.MousePosition())
+	assert.Equal(t, pixel.V(0, -1), replayed.MouseScroll())
+	assert.Equal(t, "hi", replayed.Typed())
+	assert.Equal(t, 16*time.Millisecond, replay.Clock().Since(start))
+
+	assert.True(t, replay.Next())
+	assert.False(t, replayed.Pressed(pixelgl.MouseButtonLeft))
+	assert.True(t, replayed.JustReleased(pixelgl.MouseButtonLeft))
+	assert.False(t, replayed.Repeated(pixelgl.KeyA))
+	assert.Equal(t, "", replayed.Typed())
+	assert.Equal(t, 33*time.Millisecond, replay.Clock().Since(start))
+
+	assert.False(t, replay.Next())
+	assert.NoError(t, replay.Err())
+	assert.Equal(t, 2, replay.Frames())
+}
+
+// gamepadLog describes the state of the first joystick of an Input.
+func gamepadLog(input Input) string {
+	gamepads := InputGamepads(input)
+	return fmt.Sprintf("present=%v/%v/%v name=%q counts=%d/%d button=%v/%v/%v axis=%v",
+		gamepads.JoystickPresent(Joystick1), gamepads.JoystickJustConnected(Joystick1),
+		gamepads.JoystickJustDisconnected(Joystick1), gamepads.JoystickName(Joystick1),
+		gamepads.JoystickButtonCount(Joystick1), gamepads.JoystickAxisCount(Joystick1),
+		gamepads.JoystickPressed(Joystick1, 1), gamepads.JoystickJustPressed(Joystick1, 1),
+		gamepads.JoystickJustReleased(Joystick1, 1), gamepads.JoystickAxis(Joystick1, 0))
+}
+
+func TestReplay_gamepads(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, err := NewRecorder(&buf, 1)
+	assert.NoError(t, err)
+	backend, gamepads := newTestGamepads()
+	input := WithGamepads(newTestInput(), gamepads)
+
+	frames := []func(){
+		func() { backend.Connect(Joystick1, "pad", 2, 1) },
+		func() {
+			backend.SetButton(Joystick1, 1, true)
+			backend.SetAxis(Joystick1, 0, -1)
+		},
+		func() { backend.SetButton(Joystick1, 1, false) },
+		func() { backend.Disconnect(Joystick1) },
+	}
+	var recorded []string
+	for _, frame := range frames {
+		frame()
+		gamepads.Update()
+		recorded = append(recorded, gamepadLog(input))
+		assert.NoError(t, recorder.Record(time.Millisecond, input))
+	}
+	assert.NoError(t, recorder.Close())
+
+	replay, err := NewReplay(&buf)
+	assert.NoError(t, err)
+	var replayed []string
+	for replay.Next() {
+		replayed = append(replayed, gamepadLog(replay.Input()))
+	}
+
+	assert.NoError(t, replay.Err())
+	assert.Equal(t, recorded, replayed)
+	assert.Contains(t, replayed[1], "button=true/true/false axis=-1")
+}
+
+func TestReplay_clockDoesNotSleep(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, _ := NewRecorder(&buf, 0)
+	recorder.Close()
+	replay, err := NewReplay(&buf)
+	assert.NoError(t, err)
+	replay.SetRealTime(false)
+	start := replay.Clock().Now()
+
+	replay.Clock().Sleep(time.Hour)
+
+	assert.Equal(t, time.Duration(0), replay.Clock().Since(start))
+}
+
+func TestNewReplay_notAReplay(t *testing.T) {
+	_, err := NewReplay(bytes.NewBufferString("not a replay"))
+
+	assert.Error(t, err)
+}
+
+func TestNewReplay_version(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	gob.NewEncoder(zw).Encode(replayHeader{Version: replayVersion + 1})
+	zw.Close()
+
+	_, err := NewReplay(&buf)
+
+	assert.EqualError(t, err, fmt.Sprintf("unsupported replay version %d", replayVersion+1))
+}
+
+func TestReplay_truncated(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, _ := NewRecorder(&buf, 0)
+	for i := 0; i < 100; i++ {
+		recorder.Record(time.Millisecond, newTestInput())
+	}
+	recorder.Close()
+	replay, err := NewReplay(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	assert.NoError(t, err)
+
+	for replay.Next() {
+	}
+
+	assert.Error(t, replay.Err())
+}
+
+// runInputLogWorld runs an inputLogScene on a headless World
+// for a number of frames, preparing it with setup.
+func runInputLogWorld(t *testing.T, frames int, input Input, setup func(world *World, window *HeadlessWindow)) []string {
+	scene := &inputLogScene{testScene: newTestScene("scene")}
+	window := NewHeadlessWindow(1, 1, input)
+	window.SetMaxFrames(frames)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	setup(world, window)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	return scene.log
+}
+
+func TestWorld_SetReplay(t *testing.T) {
+	var buf bytes.Buffer
+	recorder, err := NewRecorder(&buf, 7)
+	assert.NoError(t, err)
+
+	input := newTestInput()
+	recorded := runInputLogWorld(t, 6, input, func(world *World, window *HeadlessWindow) {
+		clock := NewManualClock()
+		world.SetClock(clock)
+		world.SetRecorder(recorder)
+		window.OnFrame = func(frame int, img *image.RGBA) {
+			// uneven frame times and changing input
+			clock.Advance(time.Duration(frame) * time.Millisecond)
+			input.nextFrame()
+			switch frame {
+			case 1:
+				input.press(pixelgl.KeySpace)
+				input.typed = "a"
+			case 3:
+				input.release(pixelgl.KeySpace)
+				input.mouse = pixel.V(3, 4)
+			case 4:
+				input.scroll = pixel.V(1, 0)
+			}
+		}
+	})
+	assert.NoError(t, recorder.Close())
+
+	replay, err := NewReplay(&buf)
+	assert.NoError(t, err)
+	replay.SetRealTime(false)
+	replayed := runInputLogWorld(t, 100, nil, func(world *World, window *HeadlessWindow) {
+		world.SetReplay(replay)
+	})
+
+	assert.Len(t, recorded, 6)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, 6, replay.Frames())
+}
+
+func TestWorld_SetReplay_nil(t *testing.T) {
+	input := newTestInput()
+	world := NewHeadlessWorld(NewHeadlessWindow(1, 1, input), nil)
+	clock := world.Clock()
+	var buf bytes.Buffer
+	recorder, _ := NewRecorder(&buf, 0)
+	recorder.Close()
+	replay, _ := NewReplay(&buf)
+
+	world.SetReplay(replay)
+	assert.Equal(t, replay.Input(), world.source)
+	assert.Equal(t, replay.Clock(), world.Clock())
+
+	world.SetReplay(nil)
+	assert.Equal(t, input, world.source)
+	assert.Equal(t, clock, world.Clock())
+}
@@ -0,0 +1,54 @@
+package wo
+
+import (
+	"github.com/pkg/errors"
+)
+
+// AnyScene is used in place of a scene name when routing
+// a SceneResult that can be returned by any Scene.
+const AnyScene = "*"
+
+// sceneRoute is the key of a route in a sceneRouter.
+type sceneRoute struct {
+	from   string
+	result SceneResult
+}
+
+// sceneDestination is the value of a route in a sceneRouter.
+type sceneDestination struct {
+	to         string
+	transition Transition
+}
+
+// sceneRouter maps the SceneResults returned by Scenes to
+// the name of the next Scene to run.
+type sceneRouter map[sceneRoute]sceneDestination
+
+// add adds a route from a Scene and a result to the next Scene,
+// with an optional Transition.
+func (r sceneRouter) add(from string, result SceneResult, to string, transition Transition) {
+	r[sceneRoute{from: from, result: result}] = sceneDestination{to: to, transition: transition}
+}
+
+// next returns the destination of the Scene that should run after the
+// Scene named from returned result. A route from the Scene itself is
+// preferred to a route from AnyScene.
+//
+// Done is true when no more Scenes should run: either the window
+// was closed or an error is returned. SceneResultError and results
+// without a route produce errors.
+func (r sceneRouter) next(from string, result SceneResult) (dest sceneDestination, done bool, err error) {
+	switch result {
+	case SceneResultWindowClosed:
+		return sceneDestination{}, true, nil
+	case SceneResultError:
+		return sceneDestination{}, true, errors.Errorf("scene %s returned an error", from)
+	}
+	if dest, ok := r[sceneRoute{from: from, result: result}]; ok {
+		return dest, false, nil
+	}
+	if dest, ok := r[sceneRoute{from: AnyScene, result: result}]; ok {
+		return dest, false, nil
+	}
+	return sceneDestination{}, true, errors.Errorf("scene %s returned result %d which has no route", from, result)
+}
@@ -0,0 +1,70 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testResultA SceneResult = iota
+	testResultB
+)
+
+func TestSceneRouter_next(t *testing.T) {
+	router := make(sceneRouter)
+	router.add("title", testResultA, "game", nil)
+	router.add(AnyScene, testResultB, "title", nil)
+	router.add("game", testResultB, "scores", nil)
+
+	cases := []struct {
+		name   string
+		from   string
+		result SceneResult
+		to     string
+		done   bool
+		err    bool
+	}{
+		{"specific", "title", testResultA, "game", false, false},
+		{"any", "title", testResultB, "title", false, false},
+		{"specificBeforeAny", "game", testResultB, "scores", false, false},
+		{"unmapped", "game", testResultA, "", true, true},
+		{"windowClosed", "title", SceneResultWindowClosed, "", true, false},
+		{"error", "title", SceneResultError, "", true, true},
+		{"popUnmapped", "title", SceneResultPop, "", true, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dest, done, err := router.next(c.from, c.result)
+
+			assert.Equal(t, c.to, dest.to)
+			assert.Equal(t, c.done, done)
+			if c.err {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), c.from)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSceneRouter_next_overrideRoutedError(t *testing.T) {
+	router := make(sceneRouter)
+	router.add(AnyScene, SceneResultError, "title", nil)
+
+	_, done, err := router.next("game", SceneResultError)
+
+	assert.True(t, done)
+	assert.Error(t, err)
+}
+
+func TestSceneRouter_next_transition(t *testing.T) {
+	router := make(sceneRouter)
+	transition := CrossfadeTransition(1)
+	router.add("title", testResultA, "game", transition)
+
+	dest, _, _ := router.next("title", testResultA)
+
+	assert.Equal(t, transition, dest.transition)
+}
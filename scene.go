@@ -1,9 +1,5 @@
 package wo
 
-import (
-	"github.com/faiface/pixel/pixelgl"
-)
-
 // SceneResult gives an indication as to how a Scene.Update(...) went.
 //
 // Negative values are reserved by the worldorder framework.
@@ -13,10 +9,14 @@ const (
 	// SceneResultNone indicates that a Scene has not yet reached a result
 	// and should continue
 	SceneResultNone SceneResult = -iota - 1
-	// SceneResultError indicates that an error has occurred
+	// SceneResultError indicates that an error has occurred. Scenes
+	// that implement SceneUpdateErrer can return the error itself
 	SceneResultError
 	// SceneResultWindowClosed indicates that the window was closed
 	SceneResultWindowClosed
+	// SceneResultPop indicates that the Scene should be removed from
+	// the scene stack, resuming the Scene beneath it
+	SceneResultPop
 )
 
 // Scene is an interface for describing a stage
@@ -27,8 +27,109 @@ type Scene interface {
 	Update(dt float64, input Input) SceneResult
 
 	// Draw draws onto a Canvas
-	Draw(canvas *pixelgl.Canvas)
+	Draw(canvas Canvas)
+}
+
+// Overlay is an optional interface for a Scene that is pushed on top
+// of other Scenes in a World's scene stack, such as a pause menu or a HUD.
+//
+// Scenes that do not implement Overlay are opaque and block updates
+// to the Scenes beneath them.
+type Overlay interface {
+	// Transparent returns whether the Scenes beneath this
+	// Scene should still be drawn
+	Transparent() bool
+
+	// BlocksUpdate returns whether the Scenes beneath this
+	// Scene should stop being updated
+	BlocksUpdate() bool
+}
+
+// SceneUpdateErrer is an optional interface for a Scene whose updates
+// can fail with an error. A World ends the Scene and returns the error,
+// with the name of the Scene and the frame number attached, as a
+// SceneError.
+type SceneUpdateErrer interface {
+	// UpdateErr is called instead of Update. Returning an error
+	// is the same as returning SceneResultError with a cause.
+	UpdateErr(dt float64, input Input) (SceneResult, error)
+}
+
+// SceneEnterer is an optional interface for a Scene that wants to
+// know when it is added to a World's scene stack.
+type SceneEnterer interface {
+	// Enter is called once, after the Scene is created
+	// and before its first Update
+	Enter()
+}
+
+// SceneSuspender is an optional interface for a Scene that wants to
+// know when another Scene is pushed on top of it.
+type SceneSuspender interface {
+	// Suspend is called when the Scene stops being the top Scene
+	Suspend()
+}
+
+// SceneResumer is an optional interface for a Scene that wants to
+// know when the Scene on top of it is removed.
+type SceneResumer interface {
+	// Resume is called when the Scene becomes the top Scene again
+	Resume()
+}
+
+// SceneExiter is an optional interface for a Scene that wants to
+// know when it is removed from a World's scene stack. This is a
+// good place to stop music or save state.
+type SceneExiter interface {
+	// Exit is called once, when the Scene will no longer be updated
+	Exit()
+}
+
+// SceneDisposer is an optional interface for a Scene that holds
+// resources, such as a text.Atlas or a font.Face, that should be
+// released once the Scene is finished.
+type SceneDisposer interface {
+	// Dispose is called once, after Exit, when the Scene
+	// will no longer be updated or drawn
+	Dispose()
+}
+
+// Interpolator is an optional interface for a Scene in a World that
+// updates with a fixed time step (see World.SetFixedStep).
+type Interpolator interface {
+	// Interpolate is called before every Draw with alpha, how far the
+	// current time is between the last Update and the next, from 0 to 1.
+	// Drawing the state between the previous and the current Update,
+	// such as pixel.Lerp(previous, current, alpha), gives smooth motion.
+	Interpolate(alpha float64)
 }
 
 // SceneFactory builds scenes when it is time to use it
-type SceneFactory func(canvas *pixelgl.Canvas) (Scene, error)
+type SceneFactory func(canvas Canvas) (Scene, error)
+
+// EnterScene calls Enter on a Scene if it is a SceneEnterer, as a World
+// does before the first Update of a Scene. EnterScene, UpdateScene,
+// DrawScene and ExitScene run a single Scene without a World, such as
+// in tests.
+func EnterScene(scene Scene) {
+	enterScene(scene)
+}
+
+// UpdateScene updates a Scene once the way a World does: the TimeStep
+// is given to SceneTimeStepper and SceneScheduler Scenes, Scenes that
+// are SceneUpdateErrers are updated with UpdateErr, and a Scene that
+// fails or panics returns SceneResultError with a SceneError.
+func UpdateScene(name string, scene Scene, step TimeStep, input Input) (SceneResult, error) {
+	return updateScene(&sceneEntry{name: name, scene: scene}, step, input)
+}
+
+// DrawScene draws a Scene onto a Canvas, returning a panic as a SceneError.
+func DrawScene(name string, scene Scene, canvas Canvas) error {
+	return drawScene(&sceneEntry{name: name, scene: scene}, canvas)
+}
+
+// ExitScene calls Exit and then Dispose on a Scene, as a World
+// does once a Scene is removed from its scene stack.
+func ExitScene(scene Scene) {
+	exitScene(scene)
+}
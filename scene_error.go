@@ -0,0 +1,118 @@
+package wo
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+)
+
+// errSceneResultError is the cause of a SceneError when a
+// Scene returns SceneResultError without an error.
+var errSceneResultError = errors.New("scene returned SceneResultError")
+
+// SceneError is the error returned by a World when a running Scene fails,
+// either by returning an error from UpdateErr, returning SceneResultError
+// or panicking in Update or Draw.
+type SceneError struct {
+	// Scene is the name of the Scene that failed.
+	Scene string
+
+	// Frame is the number of the frame the Scene failed on,
+	// counted from 1 when the World started running the Scene.
+	Frame int
+
+	// Err is the error returned by the Scene or
+	// the value it panicked with.
+	Err error
+
+	// Stack is the stack trace of the panic, or nil
+	// if the Scene did not panic.
+	Stack []byte
+}
+
+// Error returns the description of the error without the stack trace.
+func (e *SceneError) Error() string {
+	return fmt.Sprintf("scene %s failed on frame %d: %v", e.Scene, e.Frame, e.Err)
+}
+
+// Cause returns the error that made the Scene fail.
+func (e *SceneError) Cause() error {
+	return e.Err
+}
+
+// Format formats the error. The %+v verb includes the stack
+// trace of a panic.
+func (e *SceneError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.Error())
+		if s.Flag('+') && e.Stack != nil {
+			io.WriteString(s, "\n")
+			s.Write(e.Stack)
+		}
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// updateScene updates a Scene with the scaled time delta of a TimeStep,
+// using UpdateErr if it is a SceneUpdateErrer. The Scheduler of a
+// SceneScheduler is updated first. Failures, including panics, are
+// returned as a SceneError.
+func updateScene(entry *sceneEntry, step TimeStep, input Input) (result SceneResult, err error) {
+	defer recoverScene(entry.name, &result, &err)
+
+	timeStepScene(entry.scene, step)
+	scheduleScene(entry.scene, step)
+	if updater, ok := entry.scene.(SceneUpdateErrer); ok {
+		result, err = updater.UpdateErr(step.Dt, input)
+	} else {
+		result = entry.scene.Update(step.Dt, input)
+	}
+
+	if err == nil && result == SceneResultError {
+		err = errSceneResultError
+	}
+	if err != nil {
+		return SceneResultError, &SceneError{Scene: entry.name, Err: err}
+	}
+	return result, nil
+}
+
+// drawScene draws a Scene, returning a panic as a SceneError.
+func drawScene(entry *sceneEntry, canvas Canvas) (err error) {
+	result := SceneResultNone
+	defer recoverScene(entry.name, &result, &err)
+
+	entry.scene.Draw(canvas)
+	return nil
+}
+
+// recoverScene turns a panic into a SceneError with a stack trace.
+// It must be deferred.
+func recoverScene(name string, result *SceneResult, err *error) {
+	if r := recover(); r != nil {
+		cause, ok := r.(error)
+		if !ok {
+			cause = errors.Errorf("%v", r)
+		}
+		*result = SceneResultError
+		*err = &SceneError{
+			Scene: name,
+			Err:   errors.Wrap(cause, "panic"),
+			Stack: debug.Stack(),
+		}
+	}
+}
+
+// setSceneErrorFrame sets the frame a SceneError happened on.
+func setSceneErrorFrame(err error, frame int) error {
+	if sceneErr, ok := err.(*SceneError); ok {
+		sceneErr.Frame = frame
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package wo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingScene is a testScene that fails in UpdateErr
+// or panics in Update or Draw on a given update.
+type failingScene struct {
+	*testScene
+	failOn    int
+	err       error
+	panicDraw bool
+}
+
+func (s *failingScene) UpdateErr(dt float64, input Input) (SceneResult, error) {
+	result := s.testScene.Update(dt, input)
+	if s.updates == s.failOn && s.err != nil {
+		return SceneResultNone, s.err
+	}
+	return result, nil
+}
+
+func (s *failingScene) Draw(canvas Canvas) {
+	s.testScene.Draw(canvas)
+	if s.panicDraw && s.draws == s.failOn {
+		panic("draw failed")
+	}
+}
+
+// panickingScene panics in Update on a given update.
+type panickingScene struct {
+	*testScene
+	panicOn int
+}
+
+func (s *panickingScene) Update(dt float64, input Input) SceneResult {
+	result := s.testScene.Update(dt, input)
+	if s.updates == s.panicOn {
+		panic(fmt.Sprintf("update %d failed", s.updates))
+	}
+	return result
+}
+
+func runHeadlessScene(name string, scene Scene) (SceneResult, error) {
+	world := NewHeadlessWorld(NewHeadlessWindow(1, 1, nil), map[string]SceneFactory{
+		name: func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	return world.RunScene(name)
+}
+
+func TestSceneError_Error(t *testing.T) {
+	err := &SceneError{Scene: "game", Frame: 3, Err: errors.New("out of ammo")}
+
+	assert.Equal(t, "scene game failed on frame 3: out of ammo", err.Error())
+	assert.Equal(t, "scene game failed on frame 3: out of ammo", fmt.Sprintf("%+v", err))
+	assert.Equal(t, "out of ammo", pkgerrors.Cause(err).Error())
+}
+
+func TestSceneError_FormatStack(t *testing.T) {
+	err := &SceneError{Scene: "game", Frame: 3, Err: errors.New("out of ammo"), Stack: []byte("stack")}
+
+	assert.Equal(t, "scene game failed on frame 3: out of ammo", fmt.Sprintf("%v", err))
+	assert.Equal(t, "scene game failed on frame 3: out of ammo\nstack", fmt.Sprintf("%+v", err))
+}
+
+func TestWorld_RunScene_updateErr(t *testing.T) {
+	cause := errors.New("out of ammo")
+	scene := &failingScene{testScene: newTestScene("game"), failOn: 3, err: cause}
+
+	result, err := runHeadlessScene("game", scene)
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "scene game failed on frame 3: out of ammo")
+	if assert.IsType(t, &SceneError{}, err) {
+		sceneErr := err.(*SceneError)
+		assert.Equal(t, "game", sceneErr.Scene)
+		assert.Equal(t, 3, sceneErr.Frame)
+		assert.Equal(t, cause, sceneErr.Err)
+		assert.Nil(t, sceneErr.Stack)
+	}
+}
+
+func TestWorld_RunScene_resultError(t *testing.T) {
+	scene := newTestScene("game", SceneResultNone, SceneResultError)
+
+	result, err := runHeadlessScene("game", scene)
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "scene game failed on frame 2: scene returned SceneResultError")
+}
+
+func TestWorld_RunScene_updatePanic(t *testing.T) {
+	scene := &panickingScene{testScene: newTestScene("game"), panicOn: 2}
+
+	result, err := runHeadlessScene("game", scene)
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "scene game failed on frame 2: panic: update 2 failed")
+	if assert.IsType(t, &SceneError{}, err) {
+		assert.Contains(t, string(err.(*SceneError).Stack), "panickingScene")
+	}
+}
+
+func TestWorld_RunScene_drawPanic(t *testing.T) {
+	scene := &failingScene{testScene: newTestScene("game"), failOn: 4, panicDraw: true}
+
+	result, err := runHeadlessScene("game", scene)
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "scene game failed on frame 4: panic: draw failed")
+	if assert.IsType(t, &SceneError{}, err) {
+		assert.Contains(t, string(err.(*SceneError).Stack), "failingScene")
+	}
+}
+
+func TestWorld_RunScene_overlayError(t *testing.T) {
+	scenes := make(map[string]SceneFactory)
+	world := NewHeadlessWorld(NewHeadlessWindow(1, 1, nil), scenes)
+	scenes["base"] = func(canvas Canvas) (Scene, error) {
+		return &pushingWorldScene{testScene: newTestScene("base"), world: world, push: "pause"}, nil
+	}
+	scenes["pause"] = func(canvas Canvas) (Scene, error) {
+		return &failingScene{testScene: newTestScene("pause"), failOn: 1, err: errors.New("menu broke")}, nil
+	}
+
+	result, err := world.RunScene("base")
+
+	assert.Equal(t, SceneResultError, result)
+	assert.EqualError(t, err, "scene pause failed on frame 2: menu broke")
+}
+
+func TestWorld_Run_sceneError(t *testing.T) {
+	scene := &failingScene{testScene: newTestScene("game"), failOn: 1, err: errors.New("out of ammo")}
+	world := NewHeadlessWorld(NewHeadlessWindow(1, 1, nil), map[string]SceneFactory{
+		"game": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+
+	err := world.Run("game")
+
+	assert.EqualError(t, err, "scene game failed on frame 1: out of ammo")
+}
+
+// pushingWorldScene pushes a Scene onto a World on its first Update.
+type pushingWorldScene struct {
+	*testScene
+	world *World
+	push  string
+}
+
+func (s *pushingWorldScene) Update(dt float64, input Input) SceneResult {
+	result := s.testScene.Update(dt, input)
+	if s.updates == 1 {
+		if err := s.world.PushScene(s.push); err != nil {
+			return SceneResultError
+		}
+	}
+	return result
+}
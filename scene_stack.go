@@ -0,0 +1,208 @@
+package wo
+
+// sceneEntry is a Scene in a sceneStack along with
+// the name of the SceneFactory that created it.
+type sceneEntry struct {
+	name  string
+	scene Scene
+}
+
+// sceneStack is a stack of Scenes. The top Scene is always updated,
+// the Scenes beneath it are updated and drawn as permitted by the
+// Overlays above them.
+//
+// The stack calls the optional lifecycle interfaces of its Scenes:
+//
+//	push:    Suspend (previous top), Enter (pushed)
+//	pop:     Exit, Dispose (popped), Resume (new top)
+//	replace: Exit, Dispose (replaced), Enter (replacement)
+//	clear:   Exit, Dispose for every Scene from the top down
+type sceneStack struct {
+	entries []*sceneEntry
+}
+
+// len returns the number of Scenes in the stack.
+func (s *sceneStack) len() int {
+	return len(s.entries)
+}
+
+// top returns the top entry of the stack, or nil if the stack is empty.
+func (s *sceneStack) top() *sceneEntry {
+	if len(s.entries) == 0 {
+		return nil
+	}
+	return s.entries[len(s.entries)-1]
+}
+
+// push adds a Scene to the top of the stack.
+func (s *sceneStack) push(name string, scene Scene) {
+	if top := s.top(); top != nil {
+		suspendScene(top.scene)
+	}
+	s.entries = append(s.entries, &sceneEntry{name: name, scene: scene})
+	enterScene(scene)
+}
+
+// pop removes the top entry of the stack and returns it, or
+// nil if the stack is empty.
+func (s *sceneStack) pop() *sceneEntry {
+	top := s.top()
+	if top == nil {
+		return nil
+	}
+	s.remove(top)
+	return top
+}
+
+// replace replaces the top entry of the stack with a new
+// Scene and returns the replaced entry, if any.
+func (s *sceneStack) replace(name string, scene Scene) *sceneEntry {
+	top := s.top()
+	if top != nil {
+		s.entries = s.entries[:len(s.entries)-1]
+		exitScene(top.scene)
+	}
+	s.entries = append(s.entries, &sceneEntry{name: name, scene: scene})
+	enterScene(scene)
+	return top
+}
+
+// remove removes a particular entry from the stack. If the
+// entry was the top of the stack, the new top is resumed.
+func (s *sceneStack) remove(entry *sceneEntry) {
+	for index, e := range s.entries {
+		if e == entry {
+			wasTop := index == len(s.entries)-1
+			s.entries = append(s.entries[:index], s.entries[index+1:]...)
+			exitScene(entry.scene)
+			if top := s.top(); wasTop && top != nil {
+				resumeScene(top.scene)
+			}
+			return
+		}
+	}
+}
+
+// take removes all entries from the stack without exiting
+// them and returns them as a stack of their own.
+func (s *sceneStack) take() *sceneStack {
+	taken := &sceneStack{entries: s.entries}
+	s.entries = nil
+	return taken
+}
+
+// clear removes all entries from the stack, from the top down.
+func (s *sceneStack) clear() {
+	for len(s.entries) > 0 {
+		top := s.entries[len(s.entries)-1]
+		s.entries = s.entries[:len(s.entries)-1]
+		exitScene(top.scene)
+	}
+	s.entries = nil
+}
+
+// update updates the top Scene and every Scene beneath it until a Scene
+// that blocks updates is reached. Updates happen from the top down.
+//
+// Scenes that return SceneResultPop are removed from the stack. Any
+// other result than SceneResultNone is returned immediately. If the
+// bottom Scene is popped, SceneResultPop is returned.
+//
+// Scenes are updated with the scaled time delta of the TimeStep.
+//
+// A Scene that fails returns SceneResultError with a SceneError.
+func (s *sceneStack) update(step TimeStep, input Input) (SceneResult, error) {
+	// copy the entries so that Scenes can modify the stack during updates
+	entries := make([]*sceneEntry, len(s.entries))
+	copy(entries, s.entries)
+
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+		result, err := updateScene(entry, step, input)
+		switch result {
+		case SceneResultNone:
+		case SceneResultPop:
+			s.remove(entry)
+			if index == 0 {
+				return SceneResultPop, nil
+			}
+		default:
+			return result, err
+		}
+		if sceneBlocksUpdate(entry.scene) {
+			break
+		}
+	}
+	return SceneResultNone, nil
+}
+
+// visible returns the top Scene and every Scene beneath it until an
+// opaque Scene is reached. Scenes are ordered from the bottom up,
+// which is the order they should be drawn.
+func (s *sceneStack) visible() []*sceneEntry {
+	start := len(s.entries) - 1
+	for start > 0 && sceneTransparent(s.entries[start].scene) {
+		start--
+	}
+	if start < 0 {
+		return nil
+	}
+	return s.entries[start:]
+}
+
+// sceneBlocksUpdate returns whether a Scene prevents the
+// Scenes beneath it from being updated.
+func sceneBlocksUpdate(scene Scene) bool {
+	if overlay, ok := scene.(Overlay); ok {
+		return overlay.BlocksUpdate()
+	}
+	return true
+}
+
+// sceneTransparent returns whether a Scene allows the
+// Scenes beneath it to be drawn.
+func sceneTransparent(scene Scene) bool {
+	if overlay, ok := scene.(Overlay); ok {
+		return overlay.Transparent()
+	}
+	return false
+}
+
+// enterScene calls Enter on a Scene if it is a SceneEnterer.
+func enterScene(scene Scene) {
+	if enterer, ok := scene.(SceneEnterer); ok {
+		enterer.Enter()
+	}
+}
+
+// suspendScene calls Suspend on a Scene if it is a SceneSuspender.
+func suspendScene(scene Scene) {
+	if suspender, ok := scene.(SceneSuspender); ok {
+		suspender.Suspend()
+	}
+}
+
+// resumeScene calls Resume on a Scene if it is a SceneResumer.
+func resumeScene(scene Scene) {
+	if resumer, ok := scene.(SceneResumer); ok {
+		resumer.Resume()
+	}
+}
+
+// interpolateScene calls Interpolate on a Scene if it is an Interpolator.
+func interpolateScene(scene Scene, alpha float64) {
+	if interpolator, ok := scene.(Interpolator); ok {
+		interpolator.Interpolate(alpha)
+	}
+}
+
+// exitScene calls Exit and then Dispose on a Scene if
+// it is a SceneExiter or SceneDisposer respectively.
+func exitScene(scene Scene) {
+	if exiter, ok := scene.(SceneExiter); ok {
+		exiter.Exit()
+	}
+	if disposer, ok := scene.(SceneDisposer); ok {
+		disposer.Dispose()
+	}
+}
@@ -0,0 +1,254 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Scene   = &testScene{}
+	_ Overlay = &testOverlay{}
+)
+
+func TestSceneStack_pushPop(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base")
+	top := newTestScene("top")
+
+	assert.Nil(t, stack.top())
+	assert.Nil(t, stack.pop())
+
+	stack.push("base", base)
+	stack.push("top", top)
+
+	assert.Equal(t, 2, stack.len())
+	assert.Equal(t, "top", stack.top().name)
+
+	popped := stack.pop()
+
+	assert.Equal(t, top, popped.scene)
+	assert.Equal(t, 1, stack.len())
+	assert.Equal(t, "base", stack.top().name)
+}
+
+func TestSceneStack_update_opaqueBlocks(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base")
+	top := newTestScene("top")
+	stack.push("base", base)
+	stack.push("top", top)
+
+	result, err := stack.update(NewTimeStep(1, 1), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultNone, result)
+	assert.Equal(t, 0, base.updates)
+	assert.Equal(t, 1, top.updates)
+}
+
+func TestSceneStack_update_overlayPassesThrough(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base")
+	hud := newTestOverlay("hud", true, false)
+	stack.push("base", base)
+	stack.push("hud", hud)
+
+	stack.update(NewTimeStep(1, 1), nil)
+
+	assert.Equal(t, 1, base.updates)
+	assert.Equal(t, 1, hud.updates)
+}
+
+func TestSceneStack_update_overlayBlocks(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base")
+	pause := newTestOverlay("pause", true, true)
+	stack.push("base", base)
+	stack.push("pause", pause)
+
+	stack.update(NewTimeStep(1, 1), nil)
+
+	assert.Equal(t, 0, base.updates)
+	assert.Equal(t, 1, pause.updates)
+}
+
+func TestSceneStack_update_result(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base", SceneResult(7))
+	hud := newTestOverlay("hud", true, false)
+	stack.push("base", base)
+	stack.push("hud", hud)
+
+	result, err := stack.update(NewTimeStep(1, 1), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResult(7), result)
+	assert.Equal(t, 2, stack.len())
+}
+
+func TestSceneStack_update_pop(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base")
+	pause := newTestOverlay("pause", true, true, SceneResultPop)
+	stack.push("base", base)
+	stack.push("pause", pause)
+
+	result, err := stack.update(NewTimeStep(1, 1), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultNone, result)
+	assert.Equal(t, 1, stack.len())
+	assert.Equal(t, "base", stack.top().name)
+}
+
+func TestSceneStack_update_popBottom(t *testing.T) {
+	var stack sceneStack
+	base := newTestScene("base", SceneResultPop)
+	stack.push("base", base)
+
+	result, err := stack.update(NewTimeStep(1, 1), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SceneResultPop, result)
+	assert.Equal(t, 0, stack.len())
+}
+
+func TestSceneStack_update_pushDuringUpdate(t *testing.T) {
+	var stack sceneStack
+	pushed := newTestScene("pushed")
+	base := newTestScene("base")
+	stack.push("base", &pushingScene{testScene: base, stack: &stack, push: pushed})
+
+	stack.update(NewTimeStep(1, 1), nil)
+
+	assert.Equal(t, 2, stack.len())
+	assert.Equal(t, 1, base.updates)
+	assert.Equal(t, 0, pushed.updates)
+}
+
+func TestSceneStack_visible(t *testing.T) {
+	cases := []struct {
+		name     string
+		scenes   []Scene
+		expected int
+	}{
+		{"empty", nil, 0},
+		{"single", []Scene{newTestScene("a")}, 1},
+		{"opaque", []Scene{newTestScene("a"), newTestScene("b")}, 1},
+		{"transparent", []Scene{newTestScene("a"), newTestOverlay("b", true, true)}, 2},
+		{"opaqueOverlay", []Scene{newTestScene("a"), newTestOverlay("b", false, false)}, 1},
+		{"mixed", []Scene{newTestScene("a"), newTestScene("b"), newTestOverlay("c", true, true), newTestOverlay("d", true, false)}, 3},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var stack sceneStack
+			for _, scene := range c.scenes {
+				stack.push("", scene)
+			}
+
+			visible := stack.visible()
+
+			assert.Len(t, visible, c.expected)
+			if c.expected > 0 {
+				assert.Equal(t, stack.top(), visible[len(visible)-1])
+			}
+		})
+	}
+}
+
+// pushingScene pushes a Scene onto a sceneStack during its Update.
+type pushingScene struct {
+	*testScene
+	stack *sceneStack
+	push  Scene
+}
+
+func (s *pushingScene) Update(dt float64, input Input) SceneResult {
+	s.stack.push("pushed", s.push)
+	return s.testScene.Update(dt, input)
+}
+
+func TestSceneStack_lifecycle_push(t *testing.T) {
+	var log []string
+	var stack sceneStack
+
+	stack.push("a", newLifecycleScene("a", &log))
+	stack.push("b", newLifecycleScene("b", &log))
+
+	assert.Equal(t, []string{"a:enter", "a:suspend", "b:enter"}, log)
+}
+
+func TestSceneStack_lifecycle_pop(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	stack.push("a", newLifecycleScene("a", &log))
+	stack.push("b", newLifecycleScene("b", &log))
+	log = nil
+
+	stack.pop()
+
+	assert.Equal(t, []string{"b:exit", "b:dispose", "a:resume"}, log)
+}
+
+func TestSceneStack_lifecycle_popResult(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	stack.push("a", newLifecycleScene("a", &log))
+	stack.push("b", newLifecycleScene("b", &log, SceneResultPop))
+	log = nil
+
+	stack.update(NewTimeStep(1, 1), nil)
+
+	assert.Equal(t, []string{"b:exit", "b:dispose", "a:resume"}, log)
+}
+
+func TestSceneStack_lifecycle_replace(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	stack.push("a", newLifecycleScene("a", &log))
+	stack.push("b", newLifecycleScene("b", &log))
+	log = nil
+
+	stack.replace("c", newLifecycleScene("c", &log))
+
+	assert.Equal(t, []string{"b:exit", "b:dispose", "c:enter"}, log)
+	assert.Equal(t, 2, stack.len())
+}
+
+func TestSceneStack_lifecycle_clear(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	stack.push("a", newLifecycleScene("a", &log))
+	stack.push("b", newLifecycleScene("b", &log))
+	log = nil
+
+	stack.clear()
+
+	assert.Equal(t, []string{"b:exit", "b:dispose", "a:exit", "a:dispose"}, log)
+	assert.Equal(t, 0, stack.len())
+}
+
+func TestSceneStack_lifecycle_enterBeforeUpdate(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	scene := newLifecycleScene("a", &log)
+
+	stack.push("a", scene)
+
+	assert.Equal(t, []string{"a:enter"}, log)
+	assert.Equal(t, 0, scene.updates)
+}
+
+func TestSceneStack_lifecycle_optional(t *testing.T) {
+	var log []string
+	var stack sceneStack
+	stack.push("a", newTestScene("a"))
+	stack.push("b", newLifecycleScene("b", &log))
+	stack.push("c", newTestScene("c"))
+	stack.replace("d", newTestScene("d"))
+
+	stack.clear()
+
+	assert.Equal(t, []string{"b:enter", "b:suspend", "b:exit", "b:dispose"}, log)
+}
@@ -0,0 +1,117 @@
+package wo
+
+import "time"
+
+// testScene is a Scene that records its updates and draws
+// and returns scripted results.
+type testScene struct {
+	name string
+
+	transparent  bool
+	blocksUpdate bool
+
+	// results are returned from Update in order, after
+	// which SceneResultNone is returned.
+	results []SceneResult
+
+	updates int
+	draws   int
+}
+
+// newTestScene creates a Scene that does not implement Overlay.
+func newTestScene(name string, results ...SceneResult) *testScene {
+	return &testScene{
+		name:    name,
+		results: results,
+	}
+}
+
+func (s *testScene) Update(dt float64, input Input) SceneResult {
+	s.updates++
+	if len(s.results) == 0 {
+		return SceneResultNone
+	}
+	result := s.results[0]
+	s.results = s.results[1:]
+	return result
+}
+
+func (s *testScene) Draw(canvas Canvas) {
+	s.draws++
+}
+
+// testOverlay is a testScene that implements Overlay.
+type testOverlay struct {
+	*testScene
+}
+
+// newTestOverlay creates a Scene that implements Overlay.
+func newTestOverlay(name string, transparent, blocksUpdate bool, results ...SceneResult) *testOverlay {
+	scene := newTestScene(name, results...)
+	scene.transparent = transparent
+	scene.blocksUpdate = blocksUpdate
+	return &testOverlay{scene}
+}
+
+func (s *testOverlay) Transparent() bool {
+	return s.transparent
+}
+
+func (s *testOverlay) BlocksUpdate() bool {
+	return s.blocksUpdate
+}
+
+// lifecycleScene is a testScene that records its lifecycle events in a shared log.
+type lifecycleScene struct {
+	*testScene
+	log *[]string
+}
+
+// newLifecycleScene creates a Scene that implements every lifecycle interface.
+func newLifecycleScene(name string, log *[]string, results ...SceneResult) *lifecycleScene {
+	return &lifecycleScene{
+		testScene: newTestScene(name, results...),
+		log:       log,
+	}
+}
+
+func (s *lifecycleScene) record(event string) {
+	*s.log = append(*s.log, s.name+":"+event)
+}
+
+func (s *lifecycleScene) Enter()   { s.record("enter") }
+func (s *lifecycleScene) Suspend() { s.record("suspend") }
+func (s *lifecycleScene) Resume()  { s.record("resume") }
+func (s *lifecycleScene) Exit()    { s.record("exit") }
+func (s *lifecycleScene) Dispose() { s.record("dispose") }
+
+// stallScene is a Scene whose Update takes a long time on one frame.
+type stallScene struct {
+	clock *ManualClock
+	// stallFrame is the update that advances the clock by stall
+	stallFrame int
+	stall      time.Duration
+
+	deltas []float64
+}
+
+func (s *stallScene) Update(dt float64, input Input) SceneResult {
+	s.deltas = append(s.deltas, dt)
+	if len(s.deltas) == s.stallFrame {
+		s.clock.Advance(s.stall)
+	}
+	return SceneResultNone
+}
+
+func (s *stallScene) Draw(canvas Canvas) {}
+
+// sleepScene is a testScene whose updates take real time.
+type sleepScene struct {
+	*testScene
+	sleep time.Duration
+}
+
+func (s *sleepScene) Update(dt float64, input Input) SceneResult {
+	time.Sleep(s.sleep)
+	return s.testScene.Update(dt, input)
+}
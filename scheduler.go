@@ -0,0 +1,202 @@
+package wo
+
+// Scheduler runs functions after a delay, at an interval or every update
+// until they are done. Time only passes in a Scheduler when it is
+// updated, so timers follow the time delta of a Scene, including any
+// time scaling, rather than the wall clock.
+//
+// A Scheduler can be attached to a Scene by implementing SceneScheduler,
+// or to a wobj.Object with the wobj.RunScheduler Behavior.
+type Scheduler struct {
+	// time is the time in seconds the Scheduler has been updated for
+	time      float64
+	timers    []*Timer
+	timeScale float64
+}
+
+// NewScheduler creates a Scheduler with no timers.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		timeScale: 1,
+	}
+}
+
+// Timer is a handle to a function scheduled by a Scheduler.
+type Timer struct {
+	scheduler *Scheduler
+
+	// next is the Scheduler time the timer fires at next
+	next float64
+	// interval is the time between firings of an
+	// Every timer, or 0 if the timer fires once
+	interval float64
+
+	fire  func()
+	until func(dt float64) bool
+
+	done bool
+}
+
+// After schedules f to run once, after delay seconds.
+func (s *Scheduler) After(delay float64, f func()) *Timer {
+	return s.add(&Timer{
+		next: s.time + delay,
+		fire: f,
+	})
+}
+
+// Every schedules f to run every interval seconds, starting interval
+// seconds from now. If an update is longer than the interval, f runs
+// as many times as the interval fits in the update. An interval of 0
+// or less runs f once every update.
+func (s *Scheduler) Every(interval float64, f func()) *Timer {
+	if interval <= 0 {
+		return s.Until(func(dt float64) bool {
+			f()
+			return false
+		})
+	}
+	return s.add(&Timer{
+		next:     s.time + interval,
+		interval: interval,
+		fire:     f,
+	})
+}
+
+// Until schedules f to run every update, starting with the next, with the
+// time delta of the update until it returns true.
+func (s *Scheduler) Until(f func(dt float64) bool) *Timer {
+	return s.add(&Timer{
+		until: f,
+	})
+}
+
+// add adds a Timer to this Scheduler.
+func (s *Scheduler) add(timer *Timer) *Timer {
+	timer.scheduler = s
+	s.timers = append(s.timers, timer)
+	return timer
+}
+
+// SetTimeScale sets how fast time passes for this Scheduler relative
+// to the time delta it is updated with. Negative scales are treated as 0.
+func (s *Scheduler) SetTimeScale(scale float64) {
+	s.timeScale = clampTimeScale(scale)
+}
+
+// TimeScale returns how fast time passes for this Scheduler.
+func (s *Scheduler) TimeScale() float64 {
+	return s.timeScale
+}
+
+// Len returns the number of timers that have not finished
+// or been cancelled.
+func (s *Scheduler) Len() int {
+	count := 0
+	for _, timer := range s.timers {
+		if !timer.done {
+			count++
+		}
+	}
+	return count
+}
+
+// Clear cancels every timer.
+func (s *Scheduler) Clear() {
+	for _, timer := range s.timers {
+		timer.done = true
+	}
+	s.timers = nil
+}
+
+// Update advances time by dt seconds, scaled by the time scale of this
+// Scheduler, and runs every timer that is due. Timers run in the order
+// they were scheduled. Timers scheduled during an Update start counting
+// at the end of it.
+func (s *Scheduler) Update(dt float64) {
+	s.UpdateStep(NewTimeStep(dt, 1))
+}
+
+// UpdateStep is Update with the scaled time delta of a TimeStep.
+func (s *Scheduler) UpdateStep(step TimeStep) {
+	dt := step.Scaled(s.timeScale).Dt
+	s.time += dt
+
+	// copy the timers so that timers can be scheduled while updating
+	timers := make([]*Timer, len(s.timers))
+	copy(timers, s.timers)
+	for _, timer := range timers {
+		timer.update(s.time, dt)
+	}
+
+	active := s.timers[:0]
+	for _, timer := range s.timers {
+		if !timer.done {
+			active = append(active, timer)
+		}
+	}
+	for i := len(active); i < len(s.timers); i++ {
+		s.timers[i] = nil
+	}
+	s.timers = active
+}
+
+// update runs a timer for an update of the Scheduler
+// that ended at the time end.
+func (t *Timer) update(end, dt float64) {
+	if t.until != nil {
+		if !t.done && t.until(dt) {
+			t.done = true
+		}
+		return
+	}
+	for !t.done && t.next <= end {
+		if t.interval > 0 {
+			t.next += t.interval
+		} else {
+			t.done = true
+		}
+		t.fire()
+	}
+}
+
+// Cancel stops the timer from running again. Cancelling a
+// timer that has finished does nothing.
+func (t *Timer) Cancel() {
+	t.done = true
+}
+
+// Active returns whether the timer will still run.
+func (t *Timer) Active() bool {
+	return !t.done
+}
+
+// Remaining returns the time in seconds until the timer fires next,
+// or 0 if it has finished or runs every update.
+func (t *Timer) Remaining() float64 {
+	if t.done || t.until != nil {
+		return 0
+	}
+	remaining := t.next - t.scheduler.time
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// SceneScheduler is an optional interface for a Scene with a Scheduler
+// that a World updates before every Update of the Scene, with the same
+// time delta.
+type SceneScheduler interface {
+	// Scheduler returns the Scheduler of the Scene
+	Scheduler() *Scheduler
+}
+
+// scheduleScene updates the Scheduler of a Scene if it is a SceneScheduler.
+func scheduleScene(scene Scene, step TimeStep) {
+	if scheduled, ok := scene.(SceneScheduler); ok {
+		if scheduler := scheduled.Scheduler(); scheduler != nil {
+			scheduler.UpdateStep(step)
+		}
+	}
+}
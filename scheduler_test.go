@@ -0,0 +1,190 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_After(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	timer := s.After(1, func() { count++ })
+
+	s.Update(0.5)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 0.5, timer.Remaining())
+
+	s.Update(0.5)
+	assert.Equal(t, 1, count)
+	assert.False(t, timer.Active())
+
+	s.Update(5)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestScheduler_After_zero(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	s.After(0, func() { count++ })
+
+	s.Update(0)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestScheduler_Every(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	timer := s.Every(0.25, func() { count++ })
+
+	s.Update(0.2)
+	assert.Equal(t, 0, count)
+	s.Update(0.1)
+	assert.Equal(t, 1, count)
+	s.Update(0.6)
+	assert.Equal(t, 3, count)
+	assert.InDelta(t, 0.1, timer.Remaining(), 1e-9)
+	assert.True(t, timer.Active())
+}
+
+func TestScheduler_Every_zeroInterval(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	s.Every(0, func() { count++ })
+
+	s.Update(0)
+	s.Update(10)
+
+	assert.Equal(t, 2, count)
+}
+
+func TestScheduler_Until(t *testing.T) {
+	s := NewScheduler()
+	var dts []float64
+	timer := s.Until(func(dt float64) bool {
+		dts = append(dts, dt)
+		return len(dts) == 2
+	})
+
+	s.Update(0.1)
+	s.Update(0.2)
+	s.Update(0.3)
+
+	assert.Equal(t, []float64{0.1, 0.2}, dts)
+	assert.False(t, timer.Active())
+}
+
+func TestTimer_Cancel(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	timer := s.Every(1, func() { count++ })
+
+	s.Update(1)
+	timer.Cancel()
+	s.Update(1)
+
+	assert.Equal(t, 1, count)
+	assert.False(t, timer.Active())
+	assert.Equal(t, 0.0, timer.Remaining())
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestTimer_Cancel_duringUpdate(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	var timer *Timer
+	timer = s.Every(1, func() {
+		count++
+		timer.Cancel()
+	})
+
+	s.Update(3)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestScheduler_scheduleDuringUpdate(t *testing.T) {
+	s := NewScheduler()
+	var log []string
+	s.After(1, func() {
+		log = append(log, "first")
+		s.After(1, func() { log = append(log, "second") })
+	})
+
+	s.Update(3)
+	assert.Equal(t, []string{"first"}, log)
+
+	s.Update(1)
+	assert.Equal(t, []string{"first", "second"}, log)
+}
+
+func TestScheduler_order(t *testing.T) {
+	s := NewScheduler()
+	var log []string
+	s.After(2, func() { log = append(log, "a") })
+	s.After(1, func() { log = append(log, "b") })
+
+	s.Update(2)
+
+	assert.Equal(t, []string{"a", "b"}, log)
+}
+
+func TestScheduler_Clear(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	timer := s.After(1, func() { count++ })
+
+	s.Clear()
+	s.Update(1)
+
+	assert.Equal(t, 0, count)
+	assert.False(t, timer.Active())
+}
+
+func TestScheduler_SetTimeScale(t *testing.T) {
+	s := NewScheduler()
+	count := 0
+	s.After(1, func() { count++ })
+	s.SetTimeScale(0.5)
+
+	s.Update(1)
+	assert.Equal(t, 0, count)
+	s.UpdateStep(NewTimeStep(2, 0.5))
+	assert.Equal(t, 1, count)
+
+	s.SetTimeScale(-1)
+	assert.Equal(t, 0.0, s.TimeScale())
+}
+
+// schedulerScene is a testScene with a Scheduler.
+type schedulerScene struct {
+	*testScene
+	scheduler *Scheduler
+}
+
+func (s *schedulerScene) Scheduler() *Scheduler {
+	return s.scheduler
+}
+
+func TestWorld_SceneScheduler(t *testing.T) {
+	scene := &schedulerScene{testScene: newTestScene("scene"), scheduler: NewScheduler()}
+	var updates []int
+	scene.scheduler.Every(0.04, func() { updates = append(updates, scene.updates) })
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(5)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFps(50)
+	world.SetTimeScale(0.5)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	// 4 frames of 0.02 seconds at half speed
+	assert.Equal(t, []int{4}, updates)
+}
@@ -13,7 +13,12 @@ type SpriteSheet struct {
 	sprite  *pixel.Sprite
 	pic     *pixel.PictureData
 	frames  []pixel.Rect
+	frame   int
 	options SpriteSheetOptions
+
+	// source is the picture of a CachingLoader that the
+	// SpriteSheet follows when it is reloaded, if any
+	source *pictureSource
 }
 
 // SpriteSheetOptions specifies options for loading a SpriteSheet.
@@ -68,28 +73,53 @@ func (ss *SpriteSheet) Options() SpriteSheetOptions {
 
 // Sprite returns the Sprite with the current frame.
 func (ss *SpriteSheet) Sprite() *pixel.Sprite {
+	ss.follow()
 	return ss.sprite
 }
 
 // Frames returns the Frames defined in this SpriteSheet.
 func (ss *SpriteSheet) Frames() []pixel.Rect {
+	ss.follow()
 	return ss.frames
 }
 
 // SetFrame prepares the Sprite to be rendered with a
 // particular frame number.
 func (ss *SpriteSheet) SetFrame(frameNum int) *pixel.Sprite {
+	ss.follow()
 	ss.sprite.Set(ss.pic, ss.frames[frameNum])
+	ss.frame = frameNum
 	return ss.sprite
 }
 
+// follow switches to the PictureData of the source
+// of the SpriteSheet if it was reloaded.
+func (ss *SpriteSheet) follow() {
+	if ss.source != nil && ss.source.pic != ss.pic {
+		ss.setPicture(ss.source.pic)
+	}
+}
+
+// setPicture replaces the PictureData of the SpriteSheet, such as
+// when it is reloaded, keeping the current frame if it still exists.
+func (ss *SpriteSheet) setPicture(pic *pixel.PictureData) {
+	ss.pic = pic
+	ss.frames = makeFrames(int(pic.Bounds().H()), ss.options)
+	if ss.frame >= len(ss.frames) {
+		ss.frame = 0
+	}
+	ss.sprite.Set(pic, ss.frames[ss.frame])
+}
+
 // NumFrames returns the total number of frames
 // available in this SpriteSheet.
 func (ss *SpriteSheet) NumFrames() int {
+	ss.follow()
 	return len(ss.frames)
 }
 
 // Bounds returns the bounds of the current frame
 func (ss *SpriteSheet) Bounds() pixel.Rect {
+	ss.follow()
 	return ss.sprite.Frame()
 }
@@ -2,6 +2,7 @@ package wo
 
 import (
 	"bytes"
+	"sync"
 	"time"
 
 	"github.com/faiface/beep"
@@ -20,6 +21,7 @@ const (
 // Sound is a slice of bytes that can be decoded
 // and played on a Speaker.
 type Sound struct {
+	mu          sync.Mutex
 	samples     []byte
 	format      string
 	audioFormat beep.Format
@@ -42,13 +44,26 @@ func NewSound(format string, samples []byte) (*Sound, error) {
 
 // stream creates a new stream from a Sound so that it
 // can be played on a Speaker.
-func (s *Sound) stream() beep.Streamer {
+func (s *Sound) stream() (beep.Streamer, beep.Format) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	stream, _, err := decode(s.format, s.samples)
 	if err != nil {
 		// it decoded once before, how did it not decode correctly a second time?
 		panic(errors.Errorf("secondary decoding failed: %v", err))
 	}
-	return stream
+	return stream, s.audioFormat
+}
+
+// replace replaces the samples of the Sound with those of
+// another, such as when it is reloaded. Sounds that are
+// already playing finish with the old samples.
+func (s *Sound) replace(other *Sound) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = other.samples
+	s.format = other.format
+	s.audioFormat = other.audioFormat
 }
 
 // decode attempts to decode a sound in the given format.
@@ -118,9 +133,9 @@ func (s *Speaker) Play(sound *Sound) {
 // in the same format as this speaker. If it is not, it is resampled
 // as it plays.
 func (s *Speaker) ensureSampleRate(sound *Sound) beep.Streamer {
-	stream := sound.stream()
-	if sound.audioFormat.SampleRate != audioSampleRate {
-		return beep.Resample(audioResampleQuality, sound.audioFormat.SampleRate, audioSampleRate, stream)
+	stream, format := sound.stream()
+	if format.SampleRate != audioSampleRate {
+		return beep.Resample(audioResampleQuality, format.SampleRate, audioSampleRate, stream)
 	}
 	return stream
 }
@@ -0,0 +1,36 @@
+package wo
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// newTestWav creates a short, silent, 16-bit mono wav sound.
+func newTestWav(sampleRate int) []byte {
+	const (
+		channels      = 1
+		bitsPerSample = 16
+		samples       = 64
+	)
+	dataSize := samples * channels * bitsPerSample / 8
+
+	var b bytes.Buffer
+	write := func(v interface{}) {
+		binary.Write(&b, binary.LittleEndian, v)
+	}
+	b.WriteString("RIFF")
+	write(uint32(36 + dataSize))
+	b.WriteString("WAVE")
+	b.WriteString("fmt ")
+	write(uint32(16))
+	write(uint16(1)) // PCM
+	write(uint16(channels))
+	write(uint32(sampleRate))
+	write(uint32(sampleRate * channels * bitsPerSample / 8))
+	write(uint16(channels * bitsPerSample / 8))
+	write(uint16(bitsPerSample))
+	b.WriteString("data")
+	write(uint32(dataSize))
+	b.Write(make([]byte, dataSize))
+	return b.Bytes()
+}
@@ -0,0 +1,207 @@
+package wo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+	"github.com/faiface/pixel/text"
+	"golang.org/x/image/colornames"
+	"golang.org/x/image/font/basicfont"
+)
+
+const (
+	defaultStatsWindow = 5 * time.Second
+
+	// statsGraphFrames is the number of frames in the frame-time graph
+	statsGraphFrames = 160
+	// statsGraphHeight is the height of the frame-time graph for a
+	// frame at 60 fps, in canvas units
+	statsGraphHeight = 30
+)
+
+// FrameStats are rolling statistics of the frames
+// a World ran over a window of time.
+type FrameStats struct {
+	// Frames is the number of frames measured.
+	Frames int
+
+	// AverageFps is the average number of frames per second.
+	AverageFps float64
+
+	// P50, P95 and P99 are percentiles of the frame time: the time
+	// between the start of a frame and the start of the next.
+	P50, P95, P99 time.Duration
+
+	// Worst is the longest frame time.
+	Worst time.Duration
+
+	// AverageUpdate is the average time spent updating Scenes per frame.
+	AverageUpdate time.Duration
+
+	// AverageDraw is the average time spent drawing Scenes per frame.
+	AverageDraw time.Duration
+}
+
+// String returns the statistics on two lines.
+func (s FrameStats) String() string {
+	return fmt.Sprintf("fps %.1f  p50 %s  p95 %s  p99 %s\nworst %s  update %s  draw %s",
+		s.AverageFps, formatMs(s.P50), formatMs(s.P95), formatMs(s.P99),
+		formatMs(s.Worst), formatMs(s.AverageUpdate), formatMs(s.AverageDraw))
+}
+
+func formatMs(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
+
+// frameSample is the time spent on a single frame.
+type frameSample struct {
+	at     time.Time
+	frame  time.Duration
+	update time.Duration
+	draw   time.Duration
+}
+
+// frameRecorder keeps the frames of a window of time.
+type frameRecorder struct {
+	window  time.Duration
+	samples []frameSample
+}
+
+// record adds a frame that started at a time. Frames with
+// a frame time of zero, such as the first frame of a Scene,
+// are not measured.
+func (r *frameRecorder) record(at time.Time, frame, update, draw time.Duration) {
+	if frame <= 0 {
+		return
+	}
+	r.samples = append(r.samples, frameSample{
+		at:     at,
+		frame:  frame,
+		update: update,
+		draw:   draw,
+	})
+
+	expired := 0
+	for expired < len(r.samples) && at.Sub(r.samples[expired].at) > r.window {
+		expired++
+	}
+	r.samples = r.samples[expired:]
+}
+
+// stats computes the statistics of the recorded frames.
+func (r *frameRecorder) stats() FrameStats {
+	n := len(r.samples)
+	if n == 0 {
+		return FrameStats{}
+	}
+
+	frames := make([]time.Duration, n)
+	var total, update, draw time.Duration
+	for i, sample := range r.samples {
+		frames[i] = sample.frame
+		total += sample.frame
+		update += sample.update
+		draw += sample.draw
+	}
+	sort.Slice(frames, func(i, j int) bool { return frames[i] < frames[j] })
+
+	return FrameStats{
+		Frames:        n,
+		AverageFps:    float64(n) / total.Seconds(),
+		P50:           percentile(frames, 0.50),
+		P95:           percentile(frames, 0.95),
+		P99:           percentile(frames, 0.99),
+		Worst:         frames[n-1],
+		AverageUpdate: update / time.Duration(n),
+		AverageDraw:   draw / time.Duration(n),
+	}
+}
+
+// recent returns the frame times of up to count of the most recent frames.
+func (r *frameRecorder) recent(count int) []time.Duration {
+	start := len(r.samples) - count
+	if start < 0 {
+		start = 0
+	}
+	frames := make([]time.Duration, 0, len(r.samples)-start)
+	for _, sample := range r.samples[start:] {
+		frames = append(frames, sample.frame)
+	}
+	return frames
+}
+
+// percentile returns the nearest-rank percentile p, from 0 to 1,
+// of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// statsOverlay draws FrameStats and a graph of
+// recent frame times in the corner of a canvas.
+type statsOverlay struct {
+	text *text.Text
+	im   *imdraw.IMDraw
+}
+
+func newStatsOverlay() *statsOverlay {
+	atlas := text.NewAtlas(basicfont.Face7x13, text.ASCII)
+	return &statsOverlay{
+		text: text.New(pixel.ZV, atlas),
+		im:   imdraw.New(nil),
+	}
+}
+
+// draw draws the overlay onto the top left corner of the canvas.
+func (o *statsOverlay) draw(canvas Canvas, stats FrameStats, frames []time.Duration) {
+	bounds := canvas.Bounds()
+	const margin = 4
+	const lineHeight = 13
+
+	top := bounds.Max.Y - margin
+	graphBottom := top - 2*lineHeight - margin - statsGraphHeight*2
+
+	o.im.Clear()
+	o.im.Color = pixel.RGBA{A: 0.6}
+	o.im.Push(pixel.V(bounds.Min.X, graphBottom-margin), pixel.V(bounds.Min.X+2*margin+2*statsGraphFrames, bounds.Max.Y))
+	o.im.Rectangle(0)
+
+	// a line at the frame time of 60 fps
+	o.im.Color = colornames.Gray
+	target := graphBottom + statsGraphHeight
+	o.im.Push(pixel.V(bounds.Min.X+margin, target), pixel.V(bounds.Min.X+margin+2*statsGraphFrames, target))
+	o.im.Line(1)
+
+	for i, frame := range frames {
+		ratio := frame.Seconds() * 60
+		switch {
+		case ratio <= 1.1:
+			o.im.Color = colornames.Limegreen
+		case ratio <= 2:
+			o.im.Color = colornames.Gold
+		default:
+			o.im.Color = colornames.Red
+		}
+		x := bounds.Min.X + margin + float64(2*i)
+		height := math.Min(ratio, 2) * statsGraphHeight
+		o.im.Push(pixel.V(x, graphBottom), pixel.V(x+1, graphBottom+height))
+		o.im.Rectangle(0)
+	}
+
+	canvas.SetMatrix(pixel.IM)
+	o.im.Draw(canvas)
+
+	o.text.Orig = pixel.V(bounds.Min.X+margin, top-lineHeight+3)
+	o.text.Clear()
+	o.text.Dot = o.text.Orig
+	o.text.Color = colornames.White
+	fmt.Fprint(o.text, stats.String())
+	o.text.Draw(canvas, pixel.IM)
+}
@@ -0,0 +1,167 @@
+package wo
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordFrames(r *frameRecorder, frames ...time.Duration) {
+	var at time.Time
+	for _, frame := range frames {
+		at = at.Add(frame)
+		r.record(at, frame, frame/4, frame/2)
+	}
+}
+
+func TestFrameRecorder_stats(t *testing.T) {
+	r := &frameRecorder{window: time.Minute}
+	frames := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		frames = append(frames, time.Duration(i)*time.Millisecond)
+	}
+	recordFrames(r, frames...)
+
+	stats := r.stats()
+
+	assert.Equal(t, 100, stats.Frames)
+	assert.InDelta(t, 100/5.05, stats.AverageFps, 1e-9)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+	assert.Equal(t, 100*time.Millisecond, stats.Worst)
+	assert.Equal(t, 12625*time.Microsecond, stats.AverageUpdate)
+	assert.Equal(t, 25250*time.Microsecond, stats.AverageDraw)
+}
+
+func TestFrameRecorder_stats_empty(t *testing.T) {
+	r := &frameRecorder{window: time.Minute}
+
+	assert.Equal(t, FrameStats{}, r.stats())
+}
+
+func TestFrameRecorder_window(t *testing.T) {
+	r := &frameRecorder{window: time.Second}
+	var at time.Time
+	r.record(at, 500*time.Millisecond, 0, 0)
+	r.record(at.Add(time.Second), 10*time.Millisecond, 0, 0)
+	r.record(at.Add(1500*time.Millisecond), 10*time.Millisecond, 0, 0)
+
+	stats := r.stats()
+
+	assert.Equal(t, 2, stats.Frames)
+	assert.Equal(t, 10*time.Millisecond, stats.Worst)
+}
+
+func TestFrameRecorder_ignoresEmptyFrames(t *testing.T) {
+	r := &frameRecorder{window: time.Minute}
+	r.record(time.Time{}, 0, time.Millisecond, time.Millisecond)
+
+	assert.Equal(t, 0, r.stats().Frames)
+}
+
+func TestFrameRecorder_recent(t *testing.T) {
+	r := &frameRecorder{window: time.Minute}
+	recordFrames(r, 1, 2, 3, 4)
+
+	assert.Equal(t, []time.Duration{3, 4}, r.recent(2))
+	assert.Equal(t, []time.Duration{1, 2, 3, 4}, r.recent(10))
+}
+
+func TestFrameStats_String(t *testing.T) {
+	stats := FrameStats{
+		AverageFps:    59.94,
+		P50:           16700 * time.Microsecond,
+		P95:           17 * time.Millisecond,
+		P99:           18 * time.Millisecond,
+		Worst:         33400 * time.Microsecond,
+		AverageUpdate: 400 * time.Microsecond,
+		AverageDraw:   1200 * time.Microsecond,
+	}
+
+	assert.Equal(t, "fps 59.9  p50 16.7ms  p95 17.0ms  p99 18.0ms\nworst 33.4ms  update 0.4ms  draw 1.2ms", stats.String())
+}
+
+func TestWorld_FrameStats(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(11)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return newTestScene("scene"), nil
+		},
+	})
+	world.SetFps(50)
+
+	_, err := world.RunScene("scene")
+	stats := world.FrameStats()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, stats.Frames)
+	assert.InDelta(t, 50, stats.AverageFps, 1e-6)
+	assert.Equal(t, 20*time.Millisecond, stats.P50)
+	assert.Equal(t, 20*time.Millisecond, stats.Worst)
+}
+
+func TestWorld_FrameStats_deltaPolicy(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(4)
+	var world *World
+	scene := &stallScene{stallFrame: 2, stall: 2 * time.Second}
+	world = NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			scene.clock = world.Clock().(*ManualClock)
+			return scene, nil
+		},
+	})
+	world.SetFps(50)
+	world.SetDeltaPolicy(DeltaPolicy{Mode: DeltaClamp, Max: 50 * time.Millisecond})
+
+	_, err := world.RunScene("scene")
+	stats := world.FrameStats()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, 0.02, 0.05, 0.02}, scene.deltas, "the stall is clamped for the Scene")
+	assert.Equal(t, 3, stats.Frames)
+	assert.Equal(t, 2*time.Second, stats.Worst, "the stall is measured")
+}
+
+func TestWorld_FrameStats_realUpdateTime(t *testing.T) {
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(4)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return &sleepScene{testScene: newTestScene("scene"), sleep: 2 * time.Millisecond}, nil
+		},
+	})
+
+	_, err := world.RunScene("scene")
+	stats := world.FrameStats()
+
+	assert.NoError(t, err)
+	assert.True(t, stats.AverageUpdate >= 2*time.Millisecond, "the ManualClock does not time updates: %v", stats.AverageUpdate)
+}
+
+func TestWorld_ToggleStatsOverlay(t *testing.T) {
+	opaqueBlack := color.RGBA{A: 0xff}
+	run := func(overlay bool) color.RGBA {
+		window := NewHeadlessWindow(400, 200, nil)
+		window.SetMaxFrames(3)
+		world := NewHeadlessWorld(window, map[string]SceneFactory{
+			"scene": func(canvas Canvas) (Scene, error) {
+				return newTestScene("scene"), nil
+			},
+		})
+		if overlay {
+			world.ToggleStatsOverlay()
+		}
+		_, err := world.RunScene("scene")
+		assert.NoError(t, err)
+		// the first bar of the frame-time graph
+		return window.Image().RGBAAt(4, 79)
+	}
+
+	assert.Equal(t, opaqueBlack, run(false))
+	assert.NotEqual(t, opaqueBlack, run(true))
+}
@@ -16,7 +16,7 @@ type titleScene struct {
 	layers wobj.Layers
 }
 
-func (w *World) createTitleScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) createTitleScene(canvas wo.Canvas) (wo.Scene, error) {
 	layers := wobj.NewLayers(numLayers)
 
 	scene := &titleScene{
@@ -42,6 +42,6 @@ func (s *titleScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return wo.SceneResultNone
 }
 
-func (s *titleScene) Draw(canvas *pixelgl.Canvas) {
+func (s *titleScene) Draw(canvas wo.Canvas) {
 	s.layers.Draw(canvas)
 }
@@ -5,8 +5,6 @@ import (
 
 	"time"
 
-	"errors"
-
 	"github.com/explodes/go-wo"
 	"github.com/explodes/go-wo/templates/multiscene/res"
 )
@@ -57,22 +55,8 @@ func (w *World) Run() error {
 	world.SetFps(fps)
 	w.input = world.Input()
 
-	currentScene := "title"
-	for {
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			return err
-		}
+	world.Route(wo.AnyScene, sceneResultGotoTitle, "title")
+	world.Route(wo.AnyScene, sceneResultGotoMain, "main")
 
-		switch result {
-		case sceneResultGotoTitle:
-			currentScene = "title"
-		case sceneResultGotoMain:
-			currentScene = "main"
-		case wo.SceneResultError:
-			return errors.New("error running scene")
-		case wo.SceneResultWindowClosed:
-			return nil
-		}
-	}
+	return world.Run("title")
 }
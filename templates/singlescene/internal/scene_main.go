@@ -23,7 +23,7 @@ type mainScene struct {
 	layers wobj.Layers
 }
 
-func (w *World) createMainScene(canvas *pixelgl.Canvas) (wo.Scene, error) {
+func (w *World) createMainScene(canvas wo.Canvas) (wo.Scene, error) {
 	layers := wobj.NewLayers(numLayers)
 
 	scene := &mainScene{
@@ -46,6 +46,6 @@ func (s *mainScene) Update(dt float64, input wo.Input) wo.SceneResult {
 	return wo.SceneResultNone
 }
 
-func (s *mainScene) Draw(canvas *pixelgl.Canvas) {
+func (s *mainScene) Draw(canvas wo.Canvas) {
 	s.layers.Draw(canvas)
 }
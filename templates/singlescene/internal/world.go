@@ -5,8 +5,6 @@ import (
 
 	"time"
 
-	"errors"
-
 	"github.com/explodes/go-wo"
 	"github.com/explodes/go-wo/templates/singlescene/res"
 )
@@ -44,17 +42,5 @@ func (w *World) Run() error {
 	world.SetFps(fps)
 	w.input = world.Input()
 
-	currentScene := "main"
-	for {
-		result, err := world.RunScene(currentScene)
-		if err != nil {
-			return err
-		}
-		switch result {
-		case wo.SceneResultError:
-			return errors.New("error running scene")
-		case wo.SceneResultWindowClosed:
-			return nil
-		}
-	}
+	return world.Run("main")
 }
@@ -20,7 +20,7 @@ func main() {
 
 	wo.Run(func() {
 		if err := w.Run(); err != nil {
-			log.Fatal(err)
+			log.Fatalf("%+v", err)
 		}
 	})
 }
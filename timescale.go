@@ -0,0 +1,55 @@
+package wo
+
+// TimeStep is the time delta of an update before and after time scaling.
+type TimeStep struct {
+	// Dt is the scaled time delta in seconds, the time that
+	// passes in the game.
+	Dt float64
+	// Unscaled is the time delta in seconds before scaling,
+	// the time that passes in the real world.
+	Unscaled float64
+	// Scale is the time scale, where 1 is normal speed, values
+	// below 1 are slow motion, 0 is paused and values above 1
+	// fast-forward.
+	Scale float64
+}
+
+// NewTimeStep creates a TimeStep for an unscaled time delta in seconds.
+func NewTimeStep(unscaled, scale float64) TimeStep {
+	return TimeStep{
+		Dt:       unscaled * scale,
+		Unscaled: unscaled,
+		Scale:    scale,
+	}
+}
+
+// Scaled returns the TimeStep scaled again by scale, such as
+// when a layer of a game runs slower than the rest of it.
+func (t TimeStep) Scaled(scale float64) TimeStep {
+	return NewTimeStep(t.Unscaled, t.Scale*scale)
+}
+
+// SceneTimeStepper is an optional interface for a Scene that wants to
+// know the time scale of a World (see World.SetTimeScale). Parts of a
+// Scene that should keep running while the game is paused or in slow
+// motion, such as a user interface, can use the unscaled time delta.
+type SceneTimeStepper interface {
+	// SetTimeStep is called before every Update with the TimeStep
+	// whose scaled time delta is passed to Update.
+	SetTimeStep(step TimeStep)
+}
+
+// timeStepScene calls SetTimeStep on a Scene if it is a SceneTimeStepper.
+func timeStepScene(scene Scene, step TimeStep) {
+	if stepper, ok := scene.(SceneTimeStepper); ok {
+		stepper.SetTimeStep(step)
+	}
+}
+
+// clampTimeScale keeps a time scale from going backwards.
+func clampTimeScale(scale float64) float64 {
+	if scale < 0 {
+		return 0
+	}
+	return scale
+}
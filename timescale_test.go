@@ -0,0 +1,98 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stepScene is a colorScene that records the TimeStep of each update.
+type stepScene struct {
+	*colorScene
+	steps []TimeStep
+}
+
+func (s *stepScene) SetTimeStep(step TimeStep) {
+	s.steps = append(s.steps, step)
+}
+
+func TestNewTimeStep(t *testing.T) {
+	step := NewTimeStep(0.5, 0.25)
+
+	assert.Equal(t, TimeStep{Dt: 0.125, Unscaled: 0.5, Scale: 0.25}, step)
+}
+
+func TestTimeStep_Scaled(t *testing.T) {
+	step := NewTimeStep(1, 0.5).Scaled(4)
+
+	assert.Equal(t, TimeStep{Dt: 2, Unscaled: 1, Scale: 2}, step)
+}
+
+func TestWorld_SetTimeScale(t *testing.T) {
+	world := NewHeadlessWorld(NewHeadlessWindow(1, 1, nil), nil)
+
+	assert.Equal(t, 1.0, world.TimeScale())
+	world.SetTimeScale(0.5)
+	assert.Equal(t, 0.5, world.TimeScale())
+	world.SetTimeScale(-1)
+	assert.Equal(t, 0.0, world.TimeScale())
+}
+
+func TestWorld_SetTimeScale_update(t *testing.T) {
+	cases := []struct {
+		name  string
+		scale float64
+	}{
+		{"paused", 0},
+		{"slowMotion", 0.5},
+		{"fastForward", 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scene := &stepScene{colorScene: &colorScene{testScene: newTestScene("scene"), color: opaqueRed}}
+			window := NewHeadlessWindow(1, 1, nil)
+			window.SetMaxFrames(3)
+			world := NewHeadlessWorld(window, map[string]SceneFactory{
+				"scene": func(canvas Canvas) (Scene, error) {
+					return scene, nil
+				},
+			})
+			world.SetFps(50)
+			world.SetTimeScale(c.scale)
+
+			_, err := world.RunScene("scene")
+
+			assert.NoError(t, err)
+			assert.Len(t, scene.dts, 3)
+			assert.Len(t, scene.steps, 3)
+			for i, step := range scene.steps[1:] {
+				assert.InDelta(t, 0.02, step.Unscaled, 1e-9)
+				assert.Equal(t, c.scale, step.Scale)
+				assert.InDelta(t, 0.02*c.scale, scene.dts[i+1], 1e-9)
+			}
+		})
+	}
+}
+
+func TestWorld_SetTimeScale_fixedStep(t *testing.T) {
+	scene := &stepScene{colorScene: &colorScene{testScene: newTestScene("scene"), color: opaqueRed}}
+	window := NewHeadlessWindow(1, 1, nil)
+	window.SetMaxFrames(3)
+	world := NewHeadlessWorld(window, map[string]SceneFactory{
+		"scene": func(canvas Canvas) (Scene, error) {
+			return scene, nil
+		},
+	})
+	world.SetFps(50)
+	world.SetFixedStep(0.01, 0)
+	world.SetTimeScale(0)
+
+	_, err := world.RunScene("scene")
+
+	assert.NoError(t, err)
+	assert.Len(t, scene.dts, 4)
+	for i, step := range scene.steps {
+		assert.Equal(t, NewTimeStep(0.01, 0), step)
+		assert.Equal(t, 0.0, scene.dts[i])
+	}
+}
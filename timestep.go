@@ -0,0 +1,49 @@
+package wo
+
+// fixedStep accumulates variable frame times and converts
+// them into a number of constant time steps.
+type fixedStep struct {
+	// step is the length of a time step in seconds
+	step float64
+	// maxSteps is the most steps that are run in a single
+	// frame, time beyond that is dropped
+	maxSteps int
+	// accumulator is the time that has not yet been stepped
+	accumulator float64
+}
+
+// enabled returns whether fixed time steps are being used.
+func (f *fixedStep) enabled() bool {
+	return f.step > 0
+}
+
+// reset drops any accumulated time.
+func (f *fixedStep) reset() {
+	f.accumulator = 0
+}
+
+// advance adds a frame time to the accumulator and returns the
+// number of steps to run. Time beyond maxSteps steps is dropped
+// so that a long stall does not cause a spiral of catching up.
+func (f *fixedStep) advance(dt float64) int {
+	f.accumulator += dt
+	if limit := float64(f.maxSteps) * f.step; f.maxSteps > 0 && f.accumulator > limit {
+		f.accumulator = limit
+	}
+	steps := 0
+	for f.accumulator >= f.step {
+		f.accumulator -= f.step
+		steps++
+	}
+	return steps
+}
+
+// alpha returns how far between the last step and the next
+// step the current time is, from 0 to 1. It is used to
+// interpolate the state of the last two steps when drawing.
+func (f *fixedStep) alpha() float64 {
+	if !f.enabled() {
+		return 1
+	}
+	return f.accumulator / f.step
+}
@@ -0,0 +1,60 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedStep_enabled(t *testing.T) {
+	assert.False(t, (&fixedStep{}).enabled())
+	assert.True(t, (&fixedStep{step: 0.1}).enabled())
+}
+
+func TestFixedStep_advance(t *testing.T) {
+	const epsilon = 0.0000001
+
+	f := &fixedStep{step: 0.25, maxSteps: 4}
+
+	assert.Equal(t, 0, f.advance(0.1))
+	assert.InDelta(t, 0.4, f.alpha(), epsilon)
+
+	assert.Equal(t, 1, f.advance(0.2))
+	assert.InDelta(t, 0.2, f.alpha(), epsilon)
+
+	assert.Equal(t, 2, f.advance(0.5))
+	assert.InDelta(t, 0.2, f.alpha(), epsilon)
+}
+
+func TestFixedStep_advance_catchUpLimit(t *testing.T) {
+	f := &fixedStep{step: 0.25, maxSteps: 4}
+
+	steps := f.advance(10)
+
+	assert.Equal(t, 4, steps)
+	assert.Equal(t, 0.0, f.alpha())
+	assert.Equal(t, 0, f.advance(0))
+}
+
+func TestFixedStep_advance_noLimit(t *testing.T) {
+	f := &fixedStep{step: 0.25}
+
+	steps := f.advance(10)
+
+	assert.Equal(t, 40, steps)
+}
+
+func TestFixedStep_reset(t *testing.T) {
+	f := &fixedStep{step: 0.25, maxSteps: 4}
+	f.advance(0.2)
+
+	f.reset()
+
+	assert.Equal(t, 0.0, f.alpha())
+}
+
+func TestFixedStep_alpha_disabled(t *testing.T) {
+	f := &fixedStep{}
+
+	assert.Equal(t, 1.0, f.alpha())
+}
@@ -0,0 +1,198 @@
+package wo
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/imdraw"
+)
+
+// Transition draws the change from the last frame of an outgoing
+// Scene to the first frames of an incoming Scene.
+type Transition interface {
+	// Duration returns the length of the Transition in seconds.
+	Duration() float64
+
+	// Draw draws the Transition onto a target. From is the last frame
+	// of the outgoing Scene, to is the current frame of the incoming
+	// Scene and progress goes from 0 to 1 over the Duration.
+	Draw(target pixel.Target, from, to pixel.Picture, progress float64)
+}
+
+// FadeTransition fades the outgoing Scene into a solid color
+// during the first half of the Transition and fades the incoming
+// Scene out of that color during the second half.
+func FadeTransition(c color.Color, duration float64) Transition {
+	return &fadeTransition{
+		color:    pixel.ToRGBA(c),
+		duration: duration,
+	}
+}
+
+// CrossfadeTransition fades the incoming Scene
+// in on top of the outgoing Scene.
+func CrossfadeTransition(duration float64) Transition {
+	return &crossfadeTransition{
+		duration: duration,
+	}
+}
+
+// SlideTransition pushes the outgoing Scene off of the canvas in
+// the given direction while the incoming Scene follows behind it.
+// Direction is typically one of (1, 0), (-1, 0), (0, 1) or (0, -1).
+func SlideTransition(direction pixel.Vec, duration float64) Transition {
+	return &slideTransition{
+		direction: direction,
+		duration:  duration,
+	}
+}
+
+// WipeTransition reveals the incoming Scene over the outgoing
+// Scene with an edge that moves in the given direction.
+// Direction is typically one of (1, 0), (-1, 0), (0, 1) or (0, -1).
+func WipeTransition(direction pixel.Vec, duration float64) Transition {
+	return &wipeTransition{
+		direction: direction,
+		duration:  duration,
+	}
+}
+
+// fadeTransition is a Transition through a solid color.
+type fadeTransition struct {
+	color    pixel.RGBA
+	duration float64
+}
+
+func (f *fadeTransition) Duration() float64 {
+	return f.duration
+}
+
+func (f *fadeTransition) Draw(target pixel.Target, from, to pixel.Picture, progress float64) {
+	pic, alpha := fadePhase(from, to, progress)
+	drawPicture(target, pic, pic.Bounds(), pixel.IM)
+
+	imd := imdraw.New(nil)
+	imd.Color = f.color.Mul(pixel.Alpha(alpha))
+	imd.Push(pic.Bounds().Min, pic.Bounds().Max)
+	imd.Rectangle(0)
+	imd.Draw(target)
+}
+
+// fadePhase returns the Picture to draw beneath the fade color
+// and the opacity of the fade color for a given progress.
+func fadePhase(from, to pixel.Picture, progress float64) (pixel.Picture, float64) {
+	if progress < 0.5 {
+		return from, progress * 2
+	}
+	return to, (1 - progress) * 2
+}
+
+// crossfadeTransition is a Transition that blends two Scenes.
+type crossfadeTransition struct {
+	duration float64
+}
+
+func (c *crossfadeTransition) Duration() float64 {
+	return c.duration
+}
+
+func (c *crossfadeTransition) Draw(target pixel.Target, from, to pixel.Picture, progress float64) {
+	drawPicture(target, from, from.Bounds(), pixel.IM)
+	sprite := pixel.NewSprite(to, to.Bounds())
+	sprite.DrawColorMask(target, pixel.IM.Moved(to.Bounds().Center()), pixel.Alpha(progress))
+}
+
+// slideTransition is a Transition that moves two Scenes across the canvas.
+type slideTransition struct {
+	direction pixel.Vec
+	duration  float64
+}
+
+func (s *slideTransition) Duration() float64 {
+	return s.duration
+}
+
+func (s *slideTransition) Draw(target pixel.Target, from, to pixel.Picture, progress float64) {
+	fromOffset, toOffset := slideOffsets(from.Bounds(), s.direction, progress)
+	drawPicture(target, from, from.Bounds(), pixel.IM.Moved(fromOffset))
+	drawPicture(target, to, to.Bounds(), pixel.IM.Moved(toOffset))
+}
+
+// slideOffsets returns how far the outgoing and incoming
+// Scenes are moved from their resting positions.
+func slideOffsets(bounds pixel.Rect, direction pixel.Vec, progress float64) (from, to pixel.Vec) {
+	shift := pixel.V(direction.X*bounds.W(), direction.Y*bounds.H())
+	return shift.Scaled(progress), shift.Scaled(progress - 1)
+}
+
+// wipeTransition is a Transition that reveals the incoming Scene.
+type wipeTransition struct {
+	direction pixel.Vec
+	duration  float64
+}
+
+func (w *wipeTransition) Duration() float64 {
+	return w.duration
+}
+
+func (w *wipeTransition) Draw(target pixel.Target, from, to pixel.Picture, progress float64) {
+	drawPicture(target, from, from.Bounds(), pixel.IM)
+	frame := wipeFrame(to.Bounds(), w.direction, progress)
+	if frame.W() > 0 && frame.H() > 0 {
+		drawPicture(target, to, frame, pixel.IM)
+	}
+}
+
+// wipeFrame returns the portion of the incoming Scene
+// that has been revealed for a given progress.
+func wipeFrame(bounds pixel.Rect, direction pixel.Vec, progress float64) pixel.Rect {
+	frame := bounds
+	w := bounds.W() * progress
+	h := bounds.H() * progress
+	switch {
+	case direction.X > 0:
+		frame.Max.X = bounds.Min.X + w
+	case direction.X < 0:
+		frame.Min.X = bounds.Max.X - w
+	case direction.Y > 0:
+		frame.Max.Y = bounds.Min.Y + h
+	case direction.Y < 0:
+		frame.Min.Y = bounds.Max.Y - h
+	}
+	return frame
+}
+
+// drawPicture draws a portion of a Picture onto a target at the same
+// position it has in the Picture, then transformed by a Matrix.
+func drawPicture(target pixel.Target, pic pixel.Picture, frame pixel.Rect, matrix pixel.Matrix) {
+	sprite := pixel.NewSprite(pic, frame)
+	sprite.Draw(target, pixel.IM.Moved(frame.Center()).Chained(matrix))
+}
+
+// sceneTransition draws a Transition between two Scenes.
+type sceneTransition struct {
+	// transition is the Transition in progress, if any
+	transition Transition
+	elapsed    float64
+
+	// from holds the last frame of the outgoing Scene
+	from Canvas
+	// to holds the current frame of the incoming Scene
+	to Canvas
+}
+
+// active returns whether a Transition is in progress.
+func (t *sceneTransition) active() bool {
+	return t.transition != nil
+}
+
+// transitionProgress returns the progress of a Transition
+// that has been running for elapsed seconds, from 0 to 1.
+func transitionProgress(transition Transition, elapsed float64) float64 {
+	duration := transition.Duration()
+	if duration <= 0 {
+		return 1
+	}
+	return math.Min(math.Max(elapsed/duration, 0), 1)
+}
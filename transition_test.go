@@ -0,0 +1,92 @@
+package wo
+
+import (
+	"testing"
+
+	"github.com/faiface/pixel"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+var (
+	_ Transition = FadeTransition(colornames.Black, 1)
+	_ Transition = CrossfadeTransition(1)
+	_ Transition = SlideTransition(pixel.V(1, 0), 1)
+	_ Transition = WipeTransition(pixel.V(1, 0), 1)
+)
+
+func TestTransitionProgress(t *testing.T) {
+	cases := []struct {
+		name     string
+		duration float64
+		elapsed  float64
+		expected float64
+	}{
+		{"start", 2, 0, 0},
+		{"middle", 2, 1, 0.5},
+		{"end", 2, 2, 1},
+		{"past", 2, 3, 1},
+		{"negative", 2, -1, 0},
+		{"instant", 0, 0, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			progress := transitionProgress(CrossfadeTransition(c.duration), c.elapsed)
+
+			assert.Equal(t, c.expected, progress)
+		})
+	}
+}
+
+func TestFadePhase(t *testing.T) {
+	from := pixel.MakePictureData(pixel.R(0, 0, 1, 1))
+	to := pixel.MakePictureData(pixel.R(0, 0, 1, 1))
+
+	pic, alpha := fadePhase(from, to, 0.25)
+	assert.Equal(t, from, pic)
+	assert.Equal(t, 0.5, alpha)
+
+	pic, alpha = fadePhase(from, to, 0.75)
+	assert.Equal(t, to, pic)
+	assert.Equal(t, 0.5, alpha)
+
+	pic, alpha = fadePhase(from, to, 1)
+	assert.Equal(t, to, pic)
+	assert.Equal(t, 0.0, alpha)
+}
+
+func TestSlideOffsets(t *testing.T) {
+	bounds := pixel.R(0, 0, 100, 50)
+
+	from, to := slideOffsets(bounds, pixel.V(-1, 0), 0.25)
+
+	assert.Equal(t, pixel.V(-25, 0), from)
+	assert.Equal(t, pixel.V(75, 0), to)
+
+	from, to = slideOffsets(bounds, pixel.V(0, 1), 1)
+
+	assert.Equal(t, pixel.V(0, 50), from)
+	assert.Equal(t, pixel.V(0, 0), to)
+}
+
+func TestWipeFrame(t *testing.T) {
+	bounds := pixel.R(0, 0, 100, 50)
+
+	cases := []struct {
+		name      string
+		direction pixel.Vec
+		expected  pixel.Rect
+	}{
+		{"right", pixel.V(1, 0), pixel.R(0, 0, 25, 50)},
+		{"left", pixel.V(-1, 0), pixel.R(75, 0, 100, 50)},
+		{"up", pixel.V(0, 1), pixel.R(0, 0, 100, 12.5)},
+		{"down", pixel.V(0, -1), pixel.R(0, 37.5, 100, 50)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			frame := wipeFrame(bounds, c.direction, 0.25)
+
+			assert.Equal(t, c.expected, frame)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package wo
+
+import (
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"golang.org/x/image/colornames"
+)
+
+// WindowOptions configure the window of a World.
+type WindowOptions struct {
+	// VSync synchronizes frames with the refresh rate of the monitor.
+	VSync bool
+
+	// Smooth filters the canvas when it is scaled onto the window.
+	// Pixel art should turn it off to keep its pixels sharp.
+	Smooth bool
+
+	// Resizable allows the user to resize the window.
+	Resizable bool
+
+	// Fit is how the canvas is scaled onto the window.
+	Fit FitMode
+
+	// Monitor, if not nil, is the monitor the window is fullscreen on.
+	// It is also the monitor used when switching to fullscreen later,
+	// which otherwise is the primary monitor.
+	Monitor *pixelgl.Monitor
+
+	// Undecorated removes the border and title bar of the window.
+	Undecorated bool
+
+	// GamepadDeadzone is how far from the center the analog axes of
+	// joysticks must be moved before they are reported as moved.
+	GamepadDeadzone float64
+}
+
+// DefaultWindowOptions returns the WindowOptions used by NewWorld:
+// a window with vsync and smoothing that cannot be resized.
+func DefaultWindowOptions() WindowOptions {
+	return WindowOptions{
+		VSync:           true,
+		Smooth:          true,
+		Fit:             FitStretch,
+		GamepadDeadzone: DefaultGamepadDeadzone,
+	}
+}
+
+// Window is where a World displays its frames and reads its Input from.
+type Window interface {
+	// Closed returns whether the Window has been closed
+	// and the World should stop running.
+	Closed() bool
+
+	// Bounds returns the rectangle of the Window.
+	Bounds() pixel.Rect
+
+	// Input returns the Input read from the Window.
+	Input() Input
+
+	// NewCanvas creates a Canvas that can be displayed by the Window.
+	NewCanvas(bounds pixel.Rect) Canvas
+
+	// Display draws a Canvas onto the Window, transformed by
+	// the given Matrix, and shows the finished frame. Parts of
+	// the Window the Canvas does not cover are black.
+	Display(canvas Canvas, matrix pixel.Matrix)
+}
+
+// FullscreenWindow is a Window that can switch between
+// fullscreen and windowed mode.
+type FullscreenWindow interface {
+	Window
+
+	// SetFullscreen switches between fullscreen and windowed mode.
+	SetFullscreen(fullscreen bool)
+
+	// Fullscreen returns whether the Window is fullscreen.
+	Fullscreen() bool
+}
+
+// glWindow is a Window backed by an OpenGL pixelgl.Window.
+// Its Input also reads the gamepads connected to the system.
+type glWindow struct {
+	win      *pixelgl.Window
+	input    Input
+	gamepads *Gamepads
+
+	// monitor is the monitor to be fullscreen on,
+	// or nil for the primary monitor
+	monitor *pixelgl.Monitor
+}
+
+var _ FullscreenWindow = &glWindow{}
+
+// newGlWindow opens a window of the given size.
+func newGlWindow(title string, width, height int, options WindowOptions) (*glWindow, error) {
+	cfg := pixelgl.WindowConfig{
+		Title:       title,
+		Bounds:      pixel.R(0, 0, float64(width), float64(height)),
+		Monitor:     options.Monitor,
+		Resizable:   options.Resizable,
+		Undecorated: options.Undecorated,
+		VSync:       options.VSync,
+	}
+	win, err := pixelgl.NewWindow(cfg)
+	if err != nil {
+		return nil, err
+	}
+	win.SetSmooth(options.Smooth)
+
+	gamepads := NewGamepads(glfwGamepads{})
+	gamepads.SetDeadzone(options.GamepadDeadzone)
+	gamepads.Update()
+
+	return &glWindow{
+		win:      win,
+		input:    WithGamepads(&windowInput{win}, gamepads),
+		gamepads: gamepads,
+		monitor:  options.Monitor,
+	}, nil
+}
+
+func (g *glWindow) Closed() bool {
+	return g.win.Closed()
+}
+
+func (g *glWindow) Bounds() pixel.Rect {
+	return g.win.Bounds()
+}
+
+func (g *glWindow) Input() Input {
+	return g.input
+}
+
+func (g *glWindow) NewCanvas(bounds pixel.Rect) Canvas {
+	return pixelgl.NewCanvas(bounds)
+}
+
+func (g *glWindow) Display(canvas Canvas, matrix pixel.Matrix) {
+	g.win.Clear(colornames.Black)
+	canvas.Draw(g.win, matrix)
+	g.win.Update()
+	g.gamepads.Update()
+}
+
+func (g *glWindow) SetFullscreen(fullscreen bool) {
+	if !fullscreen {
+		g.win.SetMonitor(nil)
+		return
+	}
+	monitor := g.monitor
+	if monitor == nil {
+		monitor = pixelgl.PrimaryMonitor()
+	}
+	g.win.SetMonitor(monitor)
+}
+
+func (g *glWindow) Fullscreen() bool {
+	return g.win.Monitor() != nil
+}
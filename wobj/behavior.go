@@ -1,5 +1,9 @@
 package wobj
 
+import (
+	"github.com/explodes/go-wo"
+)
+
 // Behavior is what happens when an object meets a condition for a given time delta
 type Behavior func(source *Object, dt float64)
 
@@ -36,3 +40,21 @@ var Movement = Behavior(func(source *Object, dt float64) {
 func FaceDirection(source *Object, dt float64) {
 	source.Rot = source.Velocity.Angle()
 }
+
+// Unscaled makes a Behavior that executes behavior with the unscaled
+// time delta of its source's TimeStep, ignoring any time scale, such
+// as for user interface effects that keep running while a game is paused.
+func Unscaled(behavior Behavior) Behavior {
+	return func(source *Object, dt float64) {
+		behavior(source, source.TimeStep().Unscaled)
+	}
+}
+
+// RunScheduler makes a Behavior that updates a Scheduler, so that its
+// timers follow the time scale of the Object's container and stop
+// once the Object is no longer updated.
+func RunScheduler(scheduler *wo.Scheduler) Behavior {
+	return func(source *Object, dt float64) {
+		scheduler.Update(dt)
+	}
+}
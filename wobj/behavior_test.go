@@ -3,6 +3,7 @@ package wobj
 import (
 	"testing"
 
+	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,3 +47,32 @@ func TestFaceDirection_zero_velocity(t *testing.T) {
 
 	assert.Equal(t, 0.0, obj.obj.Rot)
 }
+
+func TestUnscaled(t *testing.T) {
+	obj := newTestObject("")
+	obj.obj.Velocity = pixel.V(10, 0)
+	obj.obj.Steps = MakeBehaviors(Unscaled(Movement))
+	objects := NewObjects()
+	objects.Add(obj.obj)
+	objects.SetTimeScale(0)
+
+	objects.Update(0.5)
+
+	assert.Equal(t, pixel.V(5, 0), obj.obj.Pos)
+}
+
+func TestRunScheduler(t *testing.T) {
+	scheduler := wo.NewScheduler()
+	count := 0
+	scheduler.After(1, func() { count++ })
+	obj := newTestObject("")
+	obj.obj.Steps = MakeBehaviors(RunScheduler(scheduler))
+	layers := NewLayers(1)
+	layers[0].Add(obj.obj)
+	layers[0].SetTimeScale(0.5)
+
+	layers.Update(1)
+	assert.Equal(t, 0, count)
+	layers.Update(1)
+	assert.Equal(t, 1, count)
+}
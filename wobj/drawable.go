@@ -1,6 +1,8 @@
 package wobj
 
 import (
+	"image/color"
+
 	wo "github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 )
@@ -12,6 +14,9 @@ type Drawable interface {
 
 type SpriteDrawable struct {
 	Sprite *pixel.Sprite
+	// Mask is an optional color the Sprite is multiplied by,
+	// such as pixel.Alpha(0.5) to draw it half transparent.
+	Mask color.Color
 }
 
 func NewSpriteDrawable(sprite *pixel.Sprite) *SpriteDrawable {
@@ -25,11 +30,14 @@ func (s *SpriteDrawable) Bounds() pixel.Rect {
 }
 
 func (s *SpriteDrawable) Draw(target pixel.Target, matrix pixel.Matrix) {
-	s.Sprite.Draw(target, matrix)
+	s.Sprite.DrawColorMask(target, matrix, s.Mask)
 }
 
 type SpriteSheetDrawable struct {
 	Sheet *wo.SpriteSheet
+	// Mask is an optional color the current frame is multiplied by,
+	// such as pixel.Alpha(0.5) to draw it half transparent.
+	Mask color.Color
 }
 
 func NewSpriteSheetDrawable(sheet *wo.SpriteSheet) *SpriteSheetDrawable {
@@ -44,5 +52,5 @@ func (s *SpriteSheetDrawable) Bounds() pixel.Rect {
 }
 
 func (s *SpriteSheetDrawable) Draw(target pixel.Target, mat pixel.Matrix) {
-	s.Sheet.Sprite().Draw(target, mat)
+	s.Sheet.Sprite().DrawColorMask(target, mat, s.Mask)
 }
@@ -44,6 +44,21 @@ type Object struct {
 	// PostSteps is Behaviors to execute after Steps during
 	// an Update performed by Objects.
 	PostSteps Behaviors
+
+	// Pointer is optional PointerHandlers that a Pointer calls
+	// when the mouse hovers, clicks or drags this Object. Objects
+	// without PointerHandlers cannot be picked by a Pointer.
+	Pointer *PointerHandlers
+
+	// step is the TimeStep of the current or last Update
+	step wo.TimeStep
+}
+
+// TimeStep returns the TimeStep of the current, or most recent, Update
+// performed by Objects. Behaviors are executed with its scaled time
+// delta; effects that should ignore time scaling can use Unscaled.
+func (o *Object) TimeStep() wo.TimeStep {
+	return o.step
 }
 
 // Bounds gets the hitbox for this Object. Any Drawable will
@@ -53,6 +68,18 @@ func (o *Object) Bounds() pixel.Rect {
 	return pixel.R(o.Pos.X, o.Pos.Y, o.Pos.X+o.Size.X, o.Pos.Y+o.Size.Y)
 }
 
+// Contains returns whether a point is within the Bounds of this Object
+// rotated by Rot and RotNormal around their center, which is the area
+// its Drawable covers when drawn.
+func (o *Object) Contains(point pixel.Vec) bool {
+	bounds := o.Bounds().Norm()
+	if angle := o.Rot + o.RotNormal; angle != 0 {
+		center := bounds.Center()
+		point = point.Sub(center).Rotated(-angle).Add(center)
+	}
+	return bounds.Contains(point)
+}
+
 // Draw will render this Object on a target if a Drawable is associated with
 // this Object. The Object's Drawable will be scaled and translated to fit
 // this Object's Bounds. It will also be rotated by Rot radians to
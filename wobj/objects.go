@@ -2,6 +2,7 @@ package wobj
 
 import (
 	"github.com/cevaris/ordered_map"
+	"github.com/explodes/go-wo"
 	"github.com/faiface/pixel"
 )
 
@@ -44,8 +45,22 @@ func NewLayers(n int) Layers {
 
 // Update updates all Objects. Updates happen in the first layer forward.
 func (ly Layers) Update(dt float64) {
+	ly.UpdateStep(wo.NewTimeStep(dt, 1))
+}
+
+// UpdateStep updates all Objects with a TimeStep, such as one given
+// to a wo.SceneTimeStepper, that each layer scales by its own time scale.
+// Updates happen in the first layer forward.
+func (ly Layers) UpdateStep(step wo.TimeStep) {
 	for _, layer := range ly {
-		layer.Update(dt)
+		layer.UpdateStep(step)
+	}
+}
+
+// SetTimeScale sets the time scale of every layer.
+func (ly Layers) SetTimeScale(scale float64) {
+	for _, layer := range ly {
+		layer.SetTimeScale(scale)
 	}
 }
 
@@ -108,18 +123,36 @@ func (ly Layers) TagIterator(tags ...string) ObjectIterator {
 // The Tag of an Object should not be modified after being added to this
 // container.
 type Objects struct {
-	all    *ObjectSet
-	tagged objectTagMap
+	all       *ObjectSet
+	tagged    objectTagMap
+	timeScale float64
 }
 
 // NewObjects makes a new Objects container.
 func NewObjects() *Objects {
 	return &Objects{
-		all:    NewObjectSet(),
-		tagged: make(objectTagMap),
+		all:       NewObjectSet(),
+		tagged:    make(objectTagMap),
+		timeScale: 1,
 	}
 }
 
+// SetTimeScale sets how fast time passes for the Objects in this
+// container, where 1 is normal speed, values below 1 are slow motion,
+// 0 pauses and values above 1 fast-forward. Negative scales are
+// treated as 0.
+func (o *Objects) SetTimeScale(scale float64) {
+	if scale < 0 {
+		scale = 0
+	}
+	o.timeScale = scale
+}
+
+// TimeScale returns how fast time passes for the Objects in this container.
+func (o *Objects) TimeScale() float64 {
+	return o.timeScale
+}
+
 // Len returns the amount of Objects in this container
 func (o *Objects) Len() int {
 	return o.all.Len()
@@ -163,16 +196,28 @@ func (o *Objects) Contains(obj *Object) bool {
 // Update performs all PreSteps, then all Steps, then all PostSteps
 // of Object in this container.
 func (o *Objects) Update(dt float64) {
+	o.UpdateStep(wo.NewTimeStep(dt, 1))
+}
+
+// UpdateStep is Update with a TimeStep that is scaled by the time scale
+// of this container. Behaviors are executed with the scaled time delta
+// and can get the whole TimeStep from Object.TimeStep.
+func (o *Objects) UpdateStep(step wo.TimeStep) {
+	step = step.Scaled(o.timeScale)
+	dt := step.Dt
 	iter := o.all.Iterator()
 	for object, ok := iter(); ok; object, ok = iter() {
+		object.step = step
 		object.PreSteps.Execute(object, dt)
 	}
 	iter = o.all.Iterator()
 	for object, ok := iter(); ok; object, ok = iter() {
+		object.step = step
 		object.Steps.Execute(object, dt)
 	}
 	iter = o.all.Iterator()
 	for object, ok := iter(); ok; object, ok = iter() {
+		object.step = step
 		object.PostSteps.Execute(object, dt)
 	}
 }
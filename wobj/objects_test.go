@@ -3,6 +3,7 @@ package wobj
 import (
 	"testing"
 
+	"github.com/explodes/go-wo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -333,3 +334,56 @@ func TestLayers_Contains(t *testing.T) {
 
 	assert.True(t, layers.Contains(testObj.obj))
 }
+
+func TestLayers_UpdateStep_timeScale(t *testing.T) {
+	game := newTestObject("")
+	ui := newTestObject("")
+	var gameDt, uiDt float64
+	game.obj.Steps = MakeBehaviors(func(source *Object, dt float64) { gameDt = dt })
+	ui.obj.Steps = MakeBehaviors(func(source *Object, dt float64) { uiDt = dt })
+	layers := NewLayers(2)
+	layers[0].Add(game.obj)
+	layers[1].Add(ui.obj)
+	layers[0].SetTimeScale(0.5)
+
+	layers.UpdateStep(wo.NewTimeStep(1, 0.5))
+
+	assert.Equal(t, 0.25, gameDt)
+	assert.Equal(t, wo.NewTimeStep(1, 0.25), game.obj.TimeStep())
+	assert.Equal(t, 0.5, uiDt)
+	assert.Equal(t, wo.NewTimeStep(1, 0.5), ui.obj.TimeStep())
+}
+
+func TestLayers_SetTimeScale(t *testing.T) {
+	layers := NewLayers(2)
+
+	layers.SetTimeScale(2)
+
+	assert.Equal(t, 2.0, layers[0].TimeScale())
+	assert.Equal(t, 2.0, layers[1].TimeScale())
+}
+
+func TestObjects_SetTimeScale(t *testing.T) {
+	objects := NewObjects()
+
+	assert.Equal(t, 1.0, objects.TimeScale())
+	objects.SetTimeScale(-1)
+	assert.Equal(t, 0.0, objects.TimeScale())
+}
+
+func TestObjects_Update_paused(t *testing.T) {
+	testObj := newTestObject("")
+	dts := make([]float64, 0, 3)
+	record := func(source *Object, dt float64) { dts = append(dts, dt) }
+	testObj.obj.PreSteps = MakeBehaviors(record)
+	testObj.obj.Steps = MakeBehaviors(record)
+	testObj.obj.PostSteps = MakeBehaviors(record)
+	objects := NewObjects()
+	objects.Add(testObj.obj)
+	objects.SetTimeScale(0)
+
+	objects.Update(1)
+
+	assert.Equal(t, []float64{0, 0, 0}, dts)
+	assert.Equal(t, 1.0, testObj.obj.TimeStep().Unscaled)
+}
@@ -0,0 +1,196 @@
+package wobj
+
+import (
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// defaultDragThreshold is how far the mouse moves with its
+// button held down before a press becomes a drag.
+const defaultDragThreshold = 3
+
+// PointerEvent describes the mouse when a PointerHandler is called.
+type PointerEvent struct {
+	// Pos is the position of the mouse.
+	Pos pixel.Vec
+	// Delta is how far the mouse moved since the last Update.
+	Delta pixel.Vec
+	// Start is where the mouse button was pressed down,
+	// or Pos if it is not pressed.
+	Start pixel.Vec
+	// Button is the mouse button of the Pointer.
+	Button pixelgl.Button
+}
+
+// PointerHandler handles a PointerEvent on its source Object.
+type PointerHandler func(source *Object, event PointerEvent)
+
+// PointerHandlers are the PointerHandlers of an Object that a Pointer
+// calls. Any of them can be nil.
+type PointerHandlers struct {
+	// Enter is called when the mouse moves over the Object.
+	Enter PointerHandler
+	// Leave is called when the mouse moves off the Object,
+	// or onto an Object above it.
+	Leave PointerHandler
+	// Press is called when the mouse button is pressed down on the Object.
+	Press PointerHandler
+	// Release is called when the mouse button that was pressed
+	// down on the Object is released, wherever the mouse is.
+	Release PointerHandler
+	// Click is called after Release if the mouse is still on the
+	// Object and was not dragged.
+	Click PointerHandler
+	// Drag is called every Update that the mouse moves while the mouse
+	// button that was pressed down on the Object is held down, once it
+	// has moved further than the DragThreshold of the Pointer.
+	Drag PointerHandler
+}
+
+// pointerHandlers returns the PointerHandlers of an Object,
+// which are all nil if it has none.
+func pointerHandlers(object *Object) PointerHandlers {
+	if object.Pointer == nil {
+		return PointerHandlers{}
+	}
+	return *object.Pointer
+}
+
+// call calls a PointerHandler unless it is nil.
+func (f PointerHandler) call(source *Object, event PointerEvent) {
+	if f != nil {
+		f(source, event)
+	}
+}
+
+// MoveOnDrag is a PointerHandler for Drag that moves its source along with the mouse.
+func MoveOnDrag(source *Object, event PointerEvent) {
+	source.Pos = source.Pos.Add(event.Delta)
+}
+
+// Pick returns the topmost Object of an ObjectContainer that has
+// PointerHandlers and Contains a point, or nil if there is none.
+// Objects drawn later are above Objects drawn earlier, so the last
+// Layer of Layers is the top Layer.
+func Pick(objects ObjectContainer, point pixel.Vec) *Object {
+	var picked *Object
+	iter := objects.Iterator()
+	for object, ok := iter(); ok; object, ok = iter() {
+		if object.Pointer != nil && object.Contains(point) {
+			picked = object
+		}
+	}
+	return picked
+}
+
+// Pointer picks Objects under the mouse every Update and calls
+// their PointerHandlers when the mouse hovers, clicks or drags them.
+type Pointer struct {
+	// Button is the mouse button that presses, clicks and drags.
+	Button pixelgl.Button
+	// DragThreshold is how far the mouse must move with its button held
+	// down before it drags the Object it was pressed on. Clicks that
+	// move less are still clicks.
+	DragThreshold float64
+
+	camera pixel.Matrix
+
+	pos      pixel.Vec
+	hovered  *Object
+	pressed  *Object
+	start    pixel.Vec
+	dragging bool
+}
+
+// NewPointer creates a Pointer that presses with the left mouse button.
+func NewPointer() *Pointer {
+	return &Pointer{
+		Button:        pixelgl.MouseButtonLeft,
+		DragThreshold: defaultDragThreshold,
+		camera:        pixel.IM,
+	}
+}
+
+// SetCamera sets the Matrix that Objects are drawn onto the canvas with,
+// so that the mouse is picked in the same coordinates as the Objects.
+func (p *Pointer) SetCamera(camera pixel.Matrix) {
+	p.camera = camera
+}
+
+// Hovered returns the Object the mouse is over, if any.
+func (p *Pointer) Hovered() *Object {
+	return p.hovered
+}
+
+// Pressed returns the Object the mouse button was pressed down on, if it is still held.
+func (p *Pointer) Pressed() *Object {
+	return p.pressed
+}
+
+// Dragging returns whether the Pressed Object is being dragged.
+func (p *Pointer) Dragging() bool {
+	return p.dragging
+}
+
+// Update picks the Object under the mouse from an ObjectContainer, such
+// as Objects or Layers, and calls the PointerHandlers of the Objects the
+// mouse entered, left, pressed, released, clicked or dragged.
+func (p *Pointer) Update(input wo.Input, objects ObjectContainer) {
+	pos := wo.WorldMousePosition(input, p.camera)
+	delta := pos.Sub(p.pos)
+	p.pos = pos
+	event := PointerEvent{Pos: pos, Delta: delta, Start: pos, Button: p.Button}
+
+	// forget Objects that were removed
+	if p.hovered != nil && !objects.Contains(p.hovered) {
+		p.hovered = nil
+	}
+	if p.pressed != nil && !objects.Contains(p.pressed) {
+		p.pressed = nil
+		p.dragging = false
+	}
+
+	target := Pick(objects, pos)
+	if target != p.hovered {
+		if p.hovered != nil {
+			pointerHandlers(p.hovered).Leave.call(p.hovered, event)
+		}
+		p.hovered = target
+		if target != nil {
+			pointerHandlers(target).Enter.call(target, event)
+		}
+	}
+
+	if input.JustPressed(p.Button) && target != nil && p.pressed == nil {
+		p.pressed = target
+		p.start = pos
+		p.dragging = false
+		pointerHandlers(target).Press.call(target, event)
+	}
+	if p.pressed == nil {
+		return
+	}
+
+	event.Start = p.start
+	pressed := p.pressed
+	if input.Pressed(p.Button) && delta != pixel.ZV {
+		if !p.dragging && pos.Sub(p.start).Len() > p.DragThreshold {
+			p.dragging = true
+			// the drag starts from where the button was pressed
+			event.Delta = pos.Sub(p.start)
+		}
+		if p.dragging {
+			pointerHandlers(pressed).Drag.call(pressed, event)
+		}
+	}
+	if input.JustReleased(p.Button) || !input.Pressed(p.Button) {
+		dragged := p.dragging
+		p.pressed = nil
+		p.dragging = false
+		pointerHandlers(pressed).Release.call(pressed, event)
+		if !dragged && target == pressed {
+			pointerHandlers(pressed).Click.call(pressed, event)
+		}
+	}
+}
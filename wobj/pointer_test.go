@@ -0,0 +1,210 @@
+package wobj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/explodes/go-wo/wotest"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+// pointerLog records the PointerHandlers called on Objects.
+type pointerLog struct {
+	events []string
+}
+
+func (l *pointerLog) handlers(name string) *PointerHandlers {
+	record := func(event string) PointerHandler {
+		return func(source *Object, e PointerEvent) {
+			l.events = append(l.events, name+" "+event)
+		}
+	}
+	return &PointerHandlers{
+		Enter:   record("enter"),
+		Leave:   record("leave"),
+		Press:   record("press"),
+		Release: record("release"),
+		Click:   record("click"),
+		Drag:    record("drag"),
+	}
+}
+
+// take returns the recorded events and forgets them.
+func (l *pointerLog) take() []string {
+	events := l.events
+	l.events = nil
+	return events
+}
+
+func newPointerObject(pos, size pixel.Vec, handlers *PointerHandlers) *Object {
+	return &Object{Pos: pos, Size: size, Pointer: handlers}
+}
+
+// movePointer moves the mouse, updates the Pointer and ends the frame.
+func movePointer(pointer *Pointer, input *wotest.Input, objects ObjectContainer, pos pixel.Vec) {
+	input.MoveMouse(pos)
+	pointer.Update(input, objects)
+	input.NextFrame()
+}
+
+func TestObject_Contains(t *testing.T) {
+	object := newPointerObject(pixel.V(0, 0), pixel.V(10, 2), nil)
+
+	assert.True(t, object.Contains(pixel.V(9, 1)))
+	assert.False(t, object.Contains(pixel.V(5, 4)))
+
+	object.Rot = math.Pi / 2
+
+	assert.False(t, object.Contains(pixel.V(9, 1)))
+	assert.True(t, object.Contains(pixel.V(5, 5)))
+	assert.True(t, object.Contains(pixel.V(5, -3)))
+}
+
+func TestObject_Contains_negativeSize(t *testing.T) {
+	object := newPointerObject(pixel.V(10, 10), pixel.V(-10, -10), nil)
+
+	assert.True(t, object.Contains(pixel.V(5, 5)))
+}
+
+func TestPick_topmost(t *testing.T) {
+	log := &pointerLog{}
+	layers := NewLayers(2)
+	bottom := newPointerObject(pixel.V(0, 0), pixel.V(10, 10), log.handlers("bottom"))
+	middle := newPointerObject(pixel.V(5, 5), pixel.V(10, 10), log.handlers("middle"))
+	top := newPointerObject(pixel.V(0, 0), pixel.V(4, 4), log.handlers("top"))
+	ignored := newPointerObject(pixel.V(0, 0), pixel.V(20, 20), nil)
+	layers[1].Add(top)
+	layers[0].Add(bottom)
+	layers[0].Add(middle)
+	layers[1].Add(ignored)
+
+	assert.Equal(t, top, Pick(layers, pixel.V(2, 2)))
+	assert.Equal(t, middle, Pick(layers, pixel.V(8, 8)))
+	assert.Equal(t, bottom, Pick(layers, pixel.V(8, 2)))
+	assert.Nil(t, Pick(layers, pixel.V(18, 18)))
+}
+
+func TestPointer_hover(t *testing.T) {
+	log := &pointerLog{}
+	objects := NewObjects()
+	a := newPointerObject(pixel.V(0, 0), pixel.V(10, 10), log.handlers("a"))
+	b := newPointerObject(pixel.V(5, 0), pixel.V(10, 10), log.handlers("b"))
+	objects.Add(a)
+	objects.Add(b)
+	pointer := NewPointer()
+	input := wotest.NewInput()
+
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+	assert.Equal(t, []string{"a enter"}, log.take())
+	assert.Equal(t, a, pointer.Hovered())
+
+	movePointer(pointer, input, objects, pixel.V(3, 2))
+	assert.Nil(t, log.take())
+
+	movePointer(pointer, input, objects, pixel.V(7, 2))
+	assert.Equal(t, []string{"a leave", "b enter"}, log.take())
+
+	movePointer(pointer, input, objects, pixel.V(30, 2))
+	assert.Equal(t, []string{"b leave"}, log.take())
+	assert.Nil(t, pointer.Hovered())
+}
+
+func TestPointer_click(t *testing.T) {
+	log := &pointerLog{}
+	objects := NewObjects()
+	objects.Add(newPointerObject(pixel.V(0, 0), pixel.V(10, 10), log.handlers("a")))
+	pointer := NewPointer()
+	input := wotest.NewInput()
+
+	input.Press(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+	assert.Equal(t, []string{"a enter", "a press"}, log.take())
+
+	movePointer(pointer, input, objects, pixel.V(3, 3))
+	assert.Nil(t, log.take())
+
+	input.Release(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(3, 3))
+	assert.Equal(t, []string{"a release", "a click"}, log.take())
+	assert.Nil(t, pointer.Pressed())
+}
+
+func TestPointer_releaseElsewhere(t *testing.T) {
+	log := &pointerLog{}
+	objects := NewObjects()
+	objects.Add(newPointerObject(pixel.V(0, 0), pixel.V(10, 10), log.handlers("a")))
+	pointer := NewPointer()
+	pointer.DragThreshold = 100
+	input := wotest.NewInput()
+
+	input.Press(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+	log.take()
+	movePointer(pointer, input, objects, pixel.V(20, 2))
+	input.Release(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(20, 2))
+
+	assert.Equal(t, []string{"a leave", "a release"}, log.take())
+}
+
+func TestPointer_drag(t *testing.T) {
+	objects := NewObjects()
+	object := newPointerObject(pixel.V(0, 0), pixel.V(10, 10), &PointerHandlers{Drag: MoveOnDrag})
+	clicked := false
+	object.Pointer.Click = func(*Object, PointerEvent) { clicked = true }
+	objects.Add(object)
+	pointer := NewPointer()
+	input := wotest.NewInput()
+
+	input.Press(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+	movePointer(pointer, input, objects, pixel.V(3, 2))
+	assert.False(t, pointer.Dragging())
+	assert.Equal(t, pixel.V(0, 0), object.Pos)
+
+	movePointer(pointer, input, objects, pixel.V(6, 2))
+	assert.True(t, pointer.Dragging())
+	assert.Equal(t, pixel.V(4, 0), object.Pos)
+
+	movePointer(pointer, input, objects, pixel.V(7, 4))
+	assert.Equal(t, pixel.V(5, 2), object.Pos)
+
+	input.Release(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(7, 4))
+	assert.False(t, pointer.Dragging())
+	assert.False(t, clicked)
+}
+
+func TestPointer_camera(t *testing.T) {
+	log := &pointerLog{}
+	objects := NewObjects()
+	objects.Add(newPointerObject(pixel.V(100, 100), pixel.V(10, 10), log.handlers("a")))
+	pointer := NewPointer()
+	pointer.SetCamera(pixel.IM.Moved(pixel.V(-100, -100)))
+	input := wotest.NewInput()
+
+	movePointer(pointer, input, objects, pixel.V(5, 5))
+
+	assert.Equal(t, []string{"a enter"}, log.take())
+}
+
+func TestPointer_removedObject(t *testing.T) {
+	log := &pointerLog{}
+	objects := NewObjects()
+	object := newPointerObject(pixel.V(0, 0), pixel.V(10, 10), log.handlers("a"))
+	objects.Add(object)
+	pointer := NewPointer()
+	input := wotest.NewInput()
+
+	input.Press(pixelgl.MouseButtonLeft)
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+	log.take()
+	objects.Remove(object)
+	movePointer(pointer, input, objects, pixel.V(2, 2))
+
+	assert.Nil(t, log.take())
+	assert.Nil(t, pointer.Hovered())
+	assert.Nil(t, pointer.Pressed())
+}
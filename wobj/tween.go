@@ -0,0 +1,307 @@
+package wobj
+
+import (
+	"math"
+
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+)
+
+// Tween animates the properties of an Object over time. Tweens are
+// combined with Sequence, Parallel, Yoyo and Repeat, and played on
+// an Object by the Behavior made with Animate.
+//
+// Tweens that animate a property from its current value remember that
+// value the first time they are applied, so a Tween should only be
+// played on a single Object.
+type Tween interface {
+	// Duration returns the length of the Tween in seconds, which
+	// is infinite for a Tween that repeats forever.
+	Duration() float64
+
+	// Apply sets the properties of an Object to their state at
+	// a time in seconds from the start of the Tween.
+	Apply(source *Object, time float64)
+}
+
+// Animate makes a Behavior that plays a Tween on its source, advancing
+// by the time delta of every update. Once the Tween is complete the
+// Behavior does nothing.
+func Animate(tween Tween) Behavior {
+	elapsed := 0.0
+	started := false
+	return func(source *Object, dt float64) {
+		duration := tween.Duration()
+		if started && elapsed >= duration {
+			return
+		}
+		started = true
+		elapsed = math.Min(elapsed+dt, duration)
+		tween.Apply(source, elapsed)
+	}
+}
+
+// MoveTo tweens the Pos of an Object from its current value to a position.
+func MoveTo(pos pixel.Vec, duration float64, ease wo.Easing) Tween {
+	return newVecTween(func(source *Object) *pixel.Vec { return &source.Pos }, pos, duration, ease)
+}
+
+// ResizeTo tweens the Size of an Object from its current value to a size.
+func ResizeTo(size pixel.Vec, duration float64, ease wo.Easing) Tween {
+	return newVecTween(func(source *Object) *pixel.Vec { return &source.Size }, size, duration, ease)
+}
+
+// RotateTo tweens the Rot of an Object from its current value to an angle in radians.
+func RotateTo(rot float64, duration float64, ease wo.Easing) Tween {
+	var from float64
+	return newEasedTween(duration, ease, func(source *Object) {
+		from = source.Rot
+	}, func(source *Object, progress float64) {
+		source.Rot = from + (rot-from)*progress
+	})
+}
+
+// TweenValue tweens a value between two numbers, calling set with the
+// current value. It can animate anything an Object's Behaviors can
+// reach, such as the alpha of a SpriteDrawable's Mask:
+//
+//	TweenValue(1, 0, 0.5, wo.EaseOutQuad, func(source *Object, alpha float64) {
+//	  drawable.Mask = pixel.Alpha(alpha)
+//	})
+func TweenValue(from, to float64, duration float64, ease wo.Easing, set func(source *Object, value float64)) Tween {
+	return newEasedTween(duration, ease, nil, func(source *Object, progress float64) {
+		set(source, from+(to-from)*progress)
+	})
+}
+
+// Wait is a Tween that does nothing for a duration, such
+// as to pause between the Tweens of a Sequence.
+func Wait(duration float64) Tween {
+	return newEasedTween(duration, nil, nil, func(*Object, float64) {})
+}
+
+// easedTween is a Tween of a single property.
+type easedTween struct {
+	duration float64
+	ease     wo.Easing
+	// start remembers the starting value of a property, if not nil
+	start   func(source *Object)
+	started bool
+	apply   func(source *Object, progress float64)
+}
+
+func newEasedTween(duration float64, ease wo.Easing, start func(source *Object), apply func(source *Object, progress float64)) *easedTween {
+	if ease == nil {
+		ease = wo.EaseLinear
+	}
+	return &easedTween{
+		duration: duration,
+		ease:     ease,
+		start:    start,
+		apply:    apply,
+	}
+}
+
+func newVecTween(property func(source *Object) *pixel.Vec, to pixel.Vec, duration float64, ease wo.Easing) *easedTween {
+	var from pixel.Vec
+	return newEasedTween(duration, ease, func(source *Object) {
+		from = *property(source)
+	}, func(source *Object, progress float64) {
+		*property(source) = pixel.Lerp(from, to, progress)
+	})
+}
+
+func (t *easedTween) Duration() float64 {
+	return t.duration
+}
+
+func (t *easedTween) Apply(source *Object, time float64) {
+	if !t.started {
+		t.started = true
+		if t.start != nil {
+			t.start(source)
+		}
+	}
+	progress := 1.0
+	if t.duration > 0 {
+		progress = math.Max(0, math.Min(time/t.duration, 1))
+	}
+	t.apply(source, t.ease(progress))
+}
+
+// Sequence plays Tweens one after another.
+func Sequence(tweens ...Tween) Tween {
+	return &sequenceTween{
+		tweens: tweens,
+	}
+}
+
+type sequenceTween struct {
+	tweens []Tween
+	// last is the time the sequence was last applied at
+	last float64
+}
+
+func (t *sequenceTween) Duration() float64 {
+	total := 0.0
+	for _, tween := range t.tweens {
+		total += tween.Duration()
+	}
+	return total
+}
+
+// Apply applies every Tween of the sequence that was active between the
+// last time it was applied and now, so that Tweens that were skipped
+// over by a long update still end in their final state. Going back in
+// time, as in a Yoyo, applies the Tweens in reverse order.
+func (t *sequenceTween) Apply(source *Object, time float64) {
+	from, to := t.last, time
+	backwards := to < from
+	if backwards {
+		from, to = to, from
+	}
+	t.last = time
+
+	type span struct {
+		tween Tween
+		start float64
+	}
+	var spans []span
+	start := 0.0
+	for _, tween := range t.tweens {
+		end := start + tween.Duration()
+		if end >= from && start <= to {
+			spans = append(spans, span{tween: tween, start: start})
+		}
+		start = end
+	}
+	if backwards {
+		for i, j := 0, len(spans)-1; i < j; i, j = i+1, j-1 {
+			spans[i], spans[j] = spans[j], spans[i]
+		}
+	}
+	for _, s := range spans {
+		s.tween.Apply(source, math.Max(0, math.Min(time-s.start, s.tween.Duration())))
+	}
+}
+
+// Parallel plays Tweens at the same time. It lasts as long as its longest Tween.
+func Parallel(tweens ...Tween) Tween {
+	return &parallelTween{
+		tweens: tweens,
+	}
+}
+
+type parallelTween struct {
+	tweens []Tween
+}
+
+func (t *parallelTween) Duration() float64 {
+	longest := 0.0
+	for _, tween := range t.tweens {
+		longest = math.Max(longest, tween.Duration())
+	}
+	return longest
+}
+
+func (t *parallelTween) Apply(source *Object, time float64) {
+	for _, tween := range t.tweens {
+		tween.Apply(source, math.Min(time, tween.Duration()))
+	}
+}
+
+// Yoyo plays a Tween forward and then backward, ending where it began.
+func Yoyo(tween Tween) Tween {
+	return &yoyoTween{
+		tween: tween,
+	}
+}
+
+type yoyoTween struct {
+	tween Tween
+}
+
+func (t *yoyoTween) Duration() float64 {
+	return 2 * t.tween.Duration()
+}
+
+func (t *yoyoTween) Apply(source *Object, time float64) {
+	duration := t.tween.Duration()
+	if time > duration {
+		time = 2*duration - time
+	}
+	t.tween.Apply(source, math.Max(0, time))
+}
+
+// Repeat plays a Tween a number of times in a row. A negative count
+// repeats it forever. Each repetition starts from the same values as
+// the first, so Repeat(MoveTo(...)) moves from the same position every
+// time; combine it with Yoyo to go back and forth.
+func Repeat(tween Tween, count int) Tween {
+	return &repeatTween{
+		tween: tween,
+		count: count,
+	}
+}
+
+type repeatTween struct {
+	tween Tween
+	count int
+	// iteration is the repetition that was last applied
+	iteration int
+}
+
+func (t *repeatTween) Duration() float64 {
+	if t.count < 0 {
+		return math.Inf(1)
+	}
+	return float64(t.count) * t.tween.Duration()
+}
+
+func (t *repeatTween) Apply(source *Object, time float64) {
+	duration := t.tween.Duration()
+	if duration <= 0 || t.count == 0 {
+		t.tween.Apply(source, duration)
+		return
+	}
+	iteration := int(time / duration)
+	if t.count > 0 && iteration >= t.count {
+		iteration = t.count - 1
+	}
+	if iteration > t.iteration {
+		// finish the repetition that was cut short by a long update
+		t.tween.Apply(source, duration)
+	}
+	t.iteration = iteration
+	t.tween.Apply(source, time-float64(iteration)*duration)
+}
+
+// OnComplete calls f once a Tween is complete.
+func OnComplete(tween Tween, f func(source *Object)) Tween {
+	return &completeTween{
+		tween: tween,
+		f:     f,
+	}
+}
+
+type completeTween struct {
+	tween Tween
+	f     func(source *Object)
+	done  bool
+}
+
+func (t *completeTween) Duration() float64 {
+	return t.tween.Duration()
+}
+
+func (t *completeTween) Apply(source *Object, time float64) {
+	t.tween.Apply(source, time)
+	if time >= t.tween.Duration() {
+		if !t.done {
+			t.done = true
+			t.f(source)
+		}
+	} else {
+		t.done = false
+	}
+}
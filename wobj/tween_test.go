@@ -0,0 +1,177 @@
+package wobj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnimate_MoveTo(t *testing.T) {
+	obj := &Object{Pos: pixel.V(10, 0)}
+	animate := Animate(MoveTo(pixel.V(20, 10), 2, wo.EaseLinear))
+
+	animate(obj, 1)
+	assert.Equal(t, pixel.V(15, 5), obj.Pos)
+
+	animate(obj, 5)
+	assert.Equal(t, pixel.V(20, 10), obj.Pos)
+
+	obj.Pos = pixel.ZV
+	animate(obj, 1)
+	assert.Equal(t, pixel.ZV, obj.Pos, "a complete animation does nothing")
+}
+
+func TestAnimate_eased(t *testing.T) {
+	obj := &Object{}
+	animate := Animate(RotateTo(1, 1, wo.EaseInQuad))
+
+	animate(obj, 0.5)
+
+	assert.Equal(t, 0.25, obj.Rot)
+}
+
+func TestResizeTo(t *testing.T) {
+	obj := &Object{Size: pixel.V(2, 2)}
+	tween := ResizeTo(pixel.V(4, 6), 1, nil)
+
+	tween.Apply(obj, 0.5)
+
+	assert.Equal(t, pixel.V(3, 4), obj.Size)
+}
+
+func TestTweenValue(t *testing.T) {
+	var values []float64
+	tween := TweenValue(1, 0, 1, nil, func(source *Object, value float64) {
+		values = append(values, value)
+	})
+
+	tween.Apply(nil, 0)
+	tween.Apply(nil, 0.25)
+	tween.Apply(nil, 2)
+
+	assert.Equal(t, []float64{1, 0.75, 0}, values)
+}
+
+func TestSequence(t *testing.T) {
+	obj := &Object{}
+	tween := Sequence(
+		MoveTo(pixel.V(10, 0), 1, nil),
+		Wait(1),
+		MoveTo(pixel.V(10, 10), 1, nil),
+	)
+	animate := Animate(tween)
+
+	assert.Equal(t, 3.0, tween.Duration())
+	animate(obj, 0.5)
+	assert.Equal(t, pixel.V(5, 0), obj.Pos)
+	animate(obj, 1)
+	assert.Equal(t, pixel.V(10, 0), obj.Pos)
+	animate(obj, 1)
+	assert.Equal(t, pixel.V(10, 5), obj.Pos)
+}
+
+func TestSequence_skipped(t *testing.T) {
+	obj := &Object{}
+	animate := Animate(Sequence(
+		MoveTo(pixel.V(10, 0), 1, nil),
+		RotateTo(1, 1, nil),
+		MoveTo(pixel.V(10, 10), 1, nil),
+	))
+
+	animate(obj, 2.5)
+
+	assert.Equal(t, pixel.V(10, 5), obj.Pos)
+	assert.Equal(t, 1.0, obj.Rot)
+}
+
+func TestParallel(t *testing.T) {
+	obj := &Object{}
+	tween := Parallel(
+		MoveTo(pixel.V(10, 0), 1, nil),
+		RotateTo(2, 2, nil),
+	)
+	animate := Animate(tween)
+
+	assert.Equal(t, 2.0, tween.Duration())
+	animate(obj, 1.5)
+	assert.Equal(t, pixel.V(10, 0), obj.Pos)
+	assert.Equal(t, 1.5, obj.Rot)
+}
+
+func TestYoyo(t *testing.T) {
+	obj := &Object{}
+	tween := Yoyo(MoveTo(pixel.V(10, 0), 1, nil))
+	animate := Animate(tween)
+
+	assert.Equal(t, 2.0, tween.Duration())
+	animate(obj, 1)
+	assert.Equal(t, pixel.V(10, 0), obj.Pos)
+	animate(obj, 0.5)
+	assert.Equal(t, pixel.V(5, 0), obj.Pos)
+	animate(obj, 1)
+	assert.Equal(t, pixel.ZV, obj.Pos)
+}
+
+func TestYoyo_sequence(t *testing.T) {
+	obj := &Object{}
+	animate := Animate(Yoyo(Sequence(
+		MoveTo(pixel.V(10, 0), 1, nil),
+		MoveTo(pixel.V(10, 10), 1, nil),
+	)))
+
+	animate(obj, 2)
+	assert.Equal(t, pixel.V(10, 10), obj.Pos)
+	animate(obj, 2)
+	assert.Equal(t, pixel.ZV, obj.Pos)
+}
+
+func TestRepeat(t *testing.T) {
+	obj := &Object{}
+	tween := Repeat(Yoyo(MoveTo(pixel.V(10, 0), 1, nil)), 2)
+	animate := Animate(tween)
+
+	assert.Equal(t, 4.0, tween.Duration())
+	animate(obj, 2.5)
+	assert.Equal(t, pixel.V(5, 0), obj.Pos)
+	animate(obj, 2)
+	assert.Equal(t, pixel.ZV, obj.Pos)
+}
+
+func TestRepeat_forever(t *testing.T) {
+	obj := &Object{}
+	tween := Repeat(MoveTo(pixel.V(10, 0), 1, nil), -1)
+	animate := Animate(tween)
+
+	assert.True(t, math.IsInf(tween.Duration(), 1))
+	animate(obj, 100.5)
+	assert.Equal(t, pixel.V(5, 0), obj.Pos)
+}
+
+func TestOnComplete(t *testing.T) {
+	obj := &Object{}
+	var completed []*Object
+	animate := Animate(OnComplete(MoveTo(pixel.V(10, 0), 1, nil), func(source *Object) {
+		completed = append(completed, source)
+	}))
+
+	animate(obj, 0.5)
+	assert.Empty(t, completed)
+	animate(obj, 0.5)
+	assert.Equal(t, []*Object{obj}, completed)
+	animate(obj, 0.5)
+	assert.Len(t, completed, 1)
+}
+
+func TestOnComplete_repeat(t *testing.T) {
+	count := 0
+	animate := Animate(Repeat(OnComplete(Wait(1), func(*Object) { count++ }), 3))
+
+	for i := 0; i < 8; i++ {
+		animate(&Object{}, 0.5)
+	}
+
+	assert.Equal(t, 3, count)
+}
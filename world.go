@@ -24,64 +24,275 @@ func Run(run func()) {
 // a window. It is used as the highest level entry point
 // into the graphical programming of an application.
 type World struct {
-	window *pixelgl.Window
-	input  Input
-	canvas *pixelgl.Canvas
-	fit    pixel.Matrix
+	window Window
+	// source is the Input read from the window or a replay,
+	// with the mouse in window coordinates
+	source  Input
+	input   *canvasInput
+	latched *latchedInput
+	canvas  Canvas
+
+	fitMode FitMode
+	fit     pixel.Matrix
+	// fitBounds are the window bounds fit was computed for
+	fitBounds pixel.Rect
 
 	Color color.Color
 
-	fps *FpsLimiter
+	fps       *FpsLimiter
+	step      fixedStep
+	timeScale float64
+	// frame is the number of the current frame of the running Scene
+	frame int
+
+	stats        frameRecorder
+	statsOverlay *statsOverlay
+	showStats    bool
 
 	scenes map[string]SceneFactory
+	stack  sceneStack
+	routes sceneRouter
+
+	asyncScenes  map[string]AsyncSceneFactory
+	loadingScene string
+
+	transition sceneTransition
+
+	recorder *Recorder
+	replay   *Replay
+	// unreplayed are the Input and Clock to restore once a replay is removed
+	unreplayedInput Input
+	unreplayedClock Clock
+
+	reloader       AssetReloader
+	reloadInterval time.Duration
+	lastReload     time.Time
 }
 
-// NewWorld creates a world with a displayed window.
+// NewWorld creates a world with a displayed window using
+// the DefaultWindowOptions.
 // Use RunScene(name) to begin rendering a Scene.
 //
 // SceneFactories are used to load Scenes by name.
 func NewWorld(title string, width, height int, scenes map[string]SceneFactory) (*World, error) {
+	return NewWorldOptions(title, width, height, DefaultWindowOptions(), scenes)
+}
 
-	w := float64(width)
-	h := float64(height)
+// NewResizableWorld creates a world with a displayed window that can be
+// resized. The canvas Scenes are drawn onto keeps its initial size and is
+// scaled onto the window using the given FitMode whenever the window
+// changes size.
+func NewResizableWorld(title string, width, height int, fit FitMode, scenes map[string]SceneFactory) (*World, error) {
+	options := DefaultWindowOptions()
+	options.Resizable = true
+	options.Fit = fit
+	// smoothing would blur the pixels that integer scaling keeps sharp
+	options.Smooth = fit != FitIntegerScale
+	return NewWorldOptions(title, width, height, options, scenes)
+}
 
-	cfg := pixelgl.WindowConfig{
-		Title:     title,
-		Bounds:    pixel.R(0, 0, w, h),
-		VSync:     true,
-		Resizable: false,
-	}
-	window, err := pixelgl.NewWindow(cfg)
+// NewWorldOptions creates a world with a displayed window
+// configured by WindowOptions. Scenes are drawn onto a canvas
+// of width by height.
+func NewWorldOptions(title string, width, height int, options WindowOptions, scenes map[string]SceneFactory) (*World, error) {
+	window, err := newGlWindow(title, width, height, options)
 	if err != nil {
 		return nil, err
 	}
-	window.SetSmooth(true)
+	bounds := pixel.R(0, 0, float64(width), float64(height))
+	world := newWorldCanvas(window, NewSystemClock(), bounds, scenes)
+	world.SetFitMode(options.Fit)
+	return world, nil
+}
 
-	canvas := pixelgl.NewCanvas(pixel.R(0, 0, w, h))
+// newWorld creates a world displayed on a Window with a canvas the size
+// of the Window, measuring frame times with a clock.
+func newWorld(window Window, clock Clock, scenes map[string]SceneFactory) *World {
+	bounds := window.Bounds()
+	return newWorldCanvas(window, clock, pixel.R(0, 0, bounds.W(), bounds.H()), scenes)
+}
+
+// newWorldCanvas creates a world displayed on a Window with a canvas
+// of the given bounds, measuring frame times with a clock.
+func newWorldCanvas(window Window, clock Clock, bounds pixel.Rect, scenes map[string]SceneFactory) *World {
+	canvas := window.NewCanvas(bounds)
+	source := window.Input()
+	input := newCanvasInput(source)
 
 	world := &World{
-		window: window,
-		input:  &windowInput{window},
-		canvas: canvas,
-		scenes: scenes,
-		Color:  colornames.Black,
-		fps:    NewFpsLimiter(defaultFps),
-		fit:    FitAtZero(canvas.Bounds(), window.Bounds()),
+		window:  window,
+		source:  source,
+		input:   input,
+		latched: &latchedInput{Input: input},
+		canvas:  canvas,
+		scenes:  scenes,
+		routes:  make(sceneRouter),
+		Color:   colornames.Black,
+		fps:     NewFpsLimiterClock(defaultFps, clock),
+		fitMode: FitStretch,
+		stats:   frameRecorder{window: defaultStatsWindow},
+
+		timeScale: 1,
+
+		asyncScenes: make(map[string]AsyncSceneFactory),
 	}
-	return world, nil
+	world.fitToWindow()
+	return world
 }
 
 // RunScene renders a Scene until that Scene returns
 // a SceneResult other than SceneResultNone.
+//
+// If a Scene fails by returning an error from UpdateErr, returning
+// SceneResultError or panicking in Update or Draw, RunScene returns
+// SceneResultError and a *SceneError.
+//
+// The Scene is the bottom of the World's scene stack. Other
+// Scenes can be pushed on top of it while it is running with
+// PushScene and removed with PopScene or SceneResultPop.
+//
+// Every Scene still in the scene stack when RunScene returns is
+// exited and disposed, from the top down, if it implements
+// SceneExiter or SceneDisposer.
 func (w *World) RunScene(name string) (SceneResult, error) {
-	scene, err := w.createScene(name)
+	return w.RunSceneTransition(name, nil)
+}
+
+// RunSceneTransition renders a Scene like RunScene, but draws the change
+// from the last frame of the previous Scene to the first frames of this
+// Scene using a Transition. A nil Transition is an instant cut.
+//
+// Scenes added with AddAsyncScene are loaded while the loading Scene
+// is running, after which the Transition begins.
+func (w *World) RunSceneTransition(name string, transition Transition) (SceneResult, error) {
+	defer w.stack.clear()
+	return w.runScene(name, transition)
+}
+
+// runScene runs a Scene like RunSceneTransition, but leaves the scene
+// stack as it is when the Scene ends. The Scenes still in the stack are
+// exited and disposed once the next Scene has been created, so that the
+// assets they share with it are not released and loaded again.
+func (w *World) runScene(name string, transition Transition) (SceneResult, error) {
+	previous := w.stack.take()
+	scene, result, err := w.loadScene(name)
+	previous.clear()
+	if result != SceneResultNone {
+		return result, err
+	}
 	if err != nil {
 		return SceneResultError, errors.Errorf("unable to create scene %s: %v", name, err)
 	}
-	return w.runToCompletion(scene), nil
+	w.beginTransition(transition)
+	w.stack.push(name, scene)
+	return w.runToCompletion()
+}
+
+// PushScene creates a Scene by name and pushes it on top of the scene stack.
+// It receives updates starting with the next frame.
+//
+// Scenes added with AddAsyncScene are loaded before PushScene returns,
+// without showing the loading Scene.
+//
+// Scenes beneath it continue to be drawn and updated only if the pushed
+// Scene is an Overlay that is transparent or does not block updates.
+func (w *World) PushScene(name string) error {
+	scene, err := w.createScene(name)
+	if err != nil {
+		return errors.Errorf("unable to create scene %s: %v", name, err)
+	}
+	w.stack.push(name, scene)
+	return nil
+}
+
+// PopScene removes the top Scene from the scene stack, resuming the
+// Scene beneath it. Popping the bottom Scene ends the running Scene
+// with SceneResultPop.
+func (w *World) PopScene() {
+	w.stack.pop()
 }
 
-// Input gets the World's Input.
+// ReplaceScene creates a Scene by name and replaces the top Scene of
+// the scene stack with it. Like with PushScene, Scenes added with
+// AddAsyncScene are loaded before ReplaceScene returns.
+func (w *World) ReplaceScene(name string) error {
+	scene, err := w.createScene(name)
+	if err != nil {
+		return errors.Errorf("unable to create scene %s: %v", name, err)
+	}
+	w.stack.replace(name, scene)
+	return nil
+}
+
+// AddAsyncScene adds a Scene that is created by an AsyncSceneFactory.
+// When it is run with RunScene, RunSceneTransition or Run, the loading
+// Scene set with SetLoadingScene runs until the factory returns.
+func (w *World) AddAsyncScene(name string, factory AsyncSceneFactory) {
+	w.asyncScenes[name] = factory
+}
+
+// SetLoadingScene sets the name of the Scene that runs while a Scene
+// added with AddAsyncScene is loading. If the loading Scene implements
+// LoadingScene it is told how far loading has progressed. Without a
+// loading Scene the window is cleared with the World's Color.
+//
+// A result other than SceneResultNone returned by the loading Scene
+// stops waiting for the Scene being loaded and is returned by RunScene.
+func (w *World) SetLoadingScene(name string) {
+	w.loadingScene = name
+}
+
+// Route maps a SceneResult returned by the Scene named from to
+// the name of the next Scene that Run should run. Use AnyScene
+// as from to route a result returned by any Scene.
+//
+// SceneResultError and SceneResultWindowClosed cannot be routed.
+func (w *World) Route(from string, result SceneResult, to string) {
+	w.routes.add(from, result, to, nil)
+}
+
+// RouteTransition adds a route like Route, changing to the next Scene
+// using a Transition.
+func (w *World) RouteTransition(from string, result SceneResult, to string, transition Transition) {
+	w.routes.add(from, result, to, transition)
+}
+
+// Run runs Scenes beginning with the Scene named start. Each time a
+// Scene returns a result, the next Scene is chosen using the routes
+// added with Route.
+//
+// A Scene that ends is exited and disposed after the next Scene has
+// been created, so that assets both Scenes load from a CachingLoader
+// stay loaded.
+//
+// Run returns nil once the window is closed. It returns an error if a
+// Scene cannot be created, a Scene returns SceneResultError or a
+// Scene returns a result that has no route.
+func (w *World) Run(start string) error {
+	defer w.stack.clear()
+	current := sceneDestination{to: start}
+	for {
+		result, err := w.runScene(current.to, current.transition)
+		if err != nil {
+			return err
+		}
+		next, done, err := w.routes.next(current.to, result)
+		if done {
+			return err
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"from":   current.to,
+			"result": result,
+			"to":     next.to,
+		}).Debug("routing scene")
+
+		current = next
+	}
+}
+
+// Input gets the World's Input. It reports the mouse position on the
+// canvas, which the window may show scaled and moved by the FitMode.
 func (w *World) Input() Input {
 	return w.input
 }
@@ -91,21 +302,235 @@ func (w *World) SetFps(maxFps float64) {
 	w.fps.SetLimit(maxFps)
 }
 
+// SetFrameWait sets the way the World waits for the next frame.
+// FrameWaitHybrid keeps a steadier frame rate than the default,
+// FrameWaitSleep, at the cost of some CPU time.
+func (w *World) SetFrameWait(frameWait FrameWait) {
+	w.fps.SetFrameWait(frameWait)
+}
+
+// SetDeltaPolicy sets the DeltaPolicy that limits the time deltas
+// Scenes are updated with, so that a long stall does not make
+// objects jump across the screen.
+func (w *World) SetDeltaPolicy(policy DeltaPolicy) {
+	w.fps.SetDeltaPolicy(policy)
+}
+
+// SetFitMode sets how the canvas is scaled onto the window.
+func (w *World) SetFitMode(mode FitMode) {
+	w.fitMode = mode
+	w.fitBounds = pixel.Rect{}
+	w.fitToWindow()
+}
+
+// FitMode gets how the canvas is scaled onto the window.
+func (w *World) FitMode() FitMode {
+	return w.fitMode
+}
+
+// SetClock replaces the Clock the World measures time with. A
+// ManualClock makes frame times deterministic and a ScaledClock
+// runs the World faster or slower than real time.
+func (w *World) SetClock(clock Clock) {
+	w.fps.SetClock(clock)
+}
+
+// Clock returns the Clock the World measures time with.
+func (w *World) Clock() Clock {
+	return w.fps.Clock()
+}
+
+// FrameStats returns statistics of the frames of running
+// Scenes over the window of time set with SetStatsWindow.
+func (w *World) FrameStats() FrameStats {
+	return w.stats.stats()
+}
+
+// SetStatsWindow sets how far back FrameStats reach.
+// The default is 5 seconds.
+func (w *World) SetStatsWindow(window time.Duration) {
+	w.stats.window = window
+}
+
+// SetStatsOverlay shows or hides an overlay with the FrameStats
+// and a graph of recent frame times on top of the canvas.
+func (w *World) SetStatsOverlay(visible bool) {
+	w.showStats = visible
+}
+
+// ToggleStatsOverlay shows the FrameStats overlay
+// if it is hidden, or hides it if it is shown.
+func (w *World) ToggleStatsOverlay() {
+	w.SetStatsOverlay(!w.showStats)
+}
+
+// SetFullscreen switches the window between fullscreen and windowed
+// mode. It does nothing if the window is not a FullscreenWindow.
+func (w *World) SetFullscreen(fullscreen bool) {
+	if window, ok := w.window.(FullscreenWindow); ok {
+		window.SetFullscreen(fullscreen)
+	}
+}
+
+// Fullscreen returns whether the window is fullscreen.
+func (w *World) Fullscreen() bool {
+	if window, ok := w.window.(FullscreenWindow); ok {
+		return window.Fullscreen()
+	}
+	return false
+}
+
+// ToggleFullscreen switches the window from fullscreen to
+// windowed mode or from windowed to fullscreen mode.
+func (w *World) ToggleFullscreen() {
+	w.SetFullscreen(!w.Fullscreen())
+}
+
+// SetFixedStep makes the World update Scenes with a constant time-delta
+// of step seconds instead of the time since the last frame. Scenes are
+// updated as many times per frame as needed to keep up with real time,
+// but at most maxSteps times; time beyond that is dropped. A maxSteps
+// of 0 is unlimited.
+//
+// Scenes that implement Interpolator are told how far between
+// two steps each frame is drawn. A step of 0 disables fixed steps.
+func (w *World) SetFixedStep(step float64, maxSteps int) {
+	w.step = fixedStep{
+		step:     step,
+		maxSteps: maxSteps,
+	}
+}
+
+// SetTimeScale sets how fast time passes for Scenes, where 1 is normal
+// speed, values below 1 are slow motion, 0 pauses and values above 1
+// fast-forward. Negative scales are treated as 0.
+//
+// Scenes are still updated every frame, or every fixed step, but with
+// a scaled time delta. Scenes that implement SceneTimeStepper are also
+// told the unscaled time delta so that parts of them can ignore the scale.
+// Transitions and frame statistics always use unscaled time.
+func (w *World) SetTimeScale(scale float64) {
+	w.timeScale = clampTimeScale(scale)
+}
+
+// TimeScale returns how fast time passes for Scenes.
+func (w *World) TimeScale() float64 {
+	return w.timeScale
+}
+
+// SetRecorder records the Input and time delta of every frame onto a
+// Recorder, until it is set to nil. The Recorder is not closed.
+func (w *World) SetRecorder(recorder *Recorder) {
+	w.recorder = recorder
+}
+
+// SetReplay replays a recording made with a Recorder: the World reads
+// input from the Replay and measures frame times with its Clock. Running
+// a Scene ends with SceneResultWindowClosed at the end of the replay, or
+// with SceneResultError if it could not be read.
+//
+// Setting the replay to nil restores the Input and Clock of the World.
+func (w *World) SetReplay(replay *Replay) {
+	if w.replay == nil && replay != nil {
+		w.unreplayedInput = w.source
+		w.unreplayedClock = w.Clock()
+	}
+	w.replay = replay
+	if replay != nil {
+		w.setInput(replay.Input())
+		w.SetClock(replay.Clock())
+	} else if w.unreplayedInput != nil {
+		w.setInput(w.unreplayedInput)
+		w.SetClock(w.unreplayedClock)
+		w.unreplayedInput = nil
+		w.unreplayedClock = nil
+	}
+}
+
+// SetAssetReloader reloads assets that changed with an AssetReloader,
+// such as a CachingLoader created with NewDevLoader, at the start of a
+// frame at most once every interval. Reload errors are logged instead
+// of ending the Scene, so that a broken file can be fixed while the
+// World keeps running. Setting the reloader to nil stops reloading.
+func (w *World) SetAssetReloader(reloader AssetReloader, interval time.Duration) {
+	w.reloader = reloader
+	w.reloadInterval = interval
+	w.lastReload = time.Time{}
+}
+
+// setInput replaces the Input Scenes are updated with, which
+// reports the mouse position in window coordinates.
+func (w *World) setInput(input Input) {
+	w.source = input
+	w.input.setSource(input)
+}
+
+// startFrame starts a frame and returns its time delta, limited by the
+// DeltaPolicy, and the frame time that was measured. The frame is read
+// from the replay or written to the recorder if there is one. A result
+// other than SceneResultNone ends the Scene.
+func (w *World) startFrame() (float64, time.Duration, SceneResult, error) {
+	if w.replay != nil && !w.replay.Next() {
+		if err := w.replay.Err(); err != nil {
+			return 0, 0, SceneResultError, err
+		}
+		return 0, 0, SceneResultWindowClosed, nil
+	}
+	measured := w.fps.measureFrame()
+	dt := w.fps.limitDelta(measured)
+	w.fitToWindow()
+	w.reloadAssets()
+	if w.recorder != nil {
+		if err := w.recorder.Record(dt, w.source); err != nil {
+			return 0, 0, SceneResultError, err
+		}
+	}
+	return dt.Seconds(), measured, SceneResultNone, nil
+}
+
+// reloadAssets reloads assets with the reloader
+// if the reload interval has passed.
+func (w *World) reloadAssets() {
+	if w.reloader == nil {
+		return
+	}
+	now := w.Clock().Now()
+	if !w.lastReload.IsZero() && now.Sub(w.lastReload) < w.reloadInterval {
+		return
+	}
+	w.lastReload = now
+	reloaded, err := w.reloader.Reload()
+	if err != nil {
+		logrus.WithError(err).Warn("unable to reload assets")
+	}
+	if len(reloaded) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"assets": reloaded,
+		}).Info("reloaded assets")
+	}
+}
+
 // createScene loads a Scene by name using its respective SceneFactory.
 func (w *World) createScene(name string) (Scene, error) {
 	logrus.WithFields(logrus.Fields{
 		"name": name,
 	}).Debug("creating scene")
 
-	factory, ok := w.scenes[name]
-	if !ok {
+	start := w.Clock().Now()
+	var scene Scene
+	var err error
+	if factory, ok := w.scenes[name]; ok {
+		scene, err = factory(w.canvas)
+	} else if factory, ok := w.asyncScenes[name]; ok {
+		load := w.startLoad(name, factory)
+		load.wait()
+		scene, err = load.result()
+	} else {
 		return nil, errors.Errorf("scene %s does not exist", name)
 	}
-	start := time.Now()
-	scene, err := factory(w.canvas)
 
 	logrus.WithFields(logrus.Fields{
-		"creationDuration": time.Now().Sub(start),
+		"creationDuration": w.Clock().Since(start),
 		"err":              err,
 		"name":             name,
 	}).Debug("created scene")
@@ -113,31 +538,227 @@ func (w *World) createScene(name string) (Scene, error) {
 	return scene, err
 }
 
-// runToCompletion runs the update/draw cycle on a Scene until
-// that Scene returns a result other than SceneResultNone.
-func (w *World) runToCompletion(scene Scene) SceneResult {
+// loadScene creates a Scene by name. Scenes created by an AsyncSceneFactory
+// are loaded while running the loading Scene, whose result and error are
+// returned if it ends before loading is complete.
+func (w *World) loadScene(name string) (Scene, SceneResult, error) {
+	factory, ok := w.asyncScenes[name]
+	if !ok {
+		scene, err := w.createScene(name)
+		return scene, SceneResultNone, err
+	}
+
+	load := w.startLoad(name, factory)
+	w.beginTransition(nil)
+	w.stack.clear()
+	defer w.stack.clear()
+	if w.loadingScene != "" {
+		loading, err := w.createScene(w.loadingScene)
+		if err != nil {
+			load.abandon()
+			return nil, SceneResultError, errors.Errorf("unable to create loading scene %s: %v", w.loadingScene, err)
+		}
+		w.stack.push(w.loadingScene, loading)
+	}
+
+	w.fps.Reset()
+	w.step.reset()
+	w.frame = 0
+	for !load.poll() {
+		if w.window.Closed() {
+			load.abandon()
+			return nil, SceneResultWindowClosed, nil
+		}
+		dt, _, result, err := w.startFrame()
+		if result != SceneResultNone {
+			load.abandon()
+			return nil, result, err
+		}
+		w.frame++
+		if top := w.stack.top(); top != nil {
+			loadProgressScene(top.scene, load.Progress())
+			if result, err := w.update(dt); result != SceneResultNone {
+				load.abandon()
+				return nil, result, setSceneErrorFrame(err, w.frame)
+			}
+		}
+		if err := w.draw(dt); err != nil {
+			load.abandon()
+			return nil, SceneResultError, setSceneErrorFrame(err, w.frame)
+		}
+		w.present()
+		w.fps.WaitForNextFrame()
+	}
+	scene, err := load.result()
+	return scene, SceneResultNone, err
+}
+
+// startLoad begins creating a Scene with an AsyncSceneFactory.
+func (w *World) startLoad(name string, factory AsyncSceneFactory) *SceneLoad {
+	logrus.WithFields(logrus.Fields{
+		"name": name,
+	}).Debug("loading scene")
+
+	load := newSceneLoad()
+	load.start(factory, w.canvas)
+	return load
+}
+
+// runToCompletion runs the update/draw cycle on the scene stack until
+// a Scene returns a result other than SceneResultNone or fails.
+func (w *World) runToCompletion() (SceneResult, error) {
 	w.fps.Reset()
+	w.step.reset()
+	w.frame = 0
 	for !w.window.Closed() {
-		dt := w.fps.StartFrame()
-		result := scene.Update(dt, w.input)
+		dt, measured, result, err := w.startFrame()
 		if result != SceneResultNone {
-			return result
+			return result, err
 		}
+		w.frame++
+		// updates and draws are timed in real time, as the Clock of
+		// the World may be a ManualClock, a ScaledClock or a Replay's
+		start := time.Now()
+		result, err = w.update(dt)
+		if result != SceneResultNone {
+			return result, setSceneErrorFrame(err, w.frame)
+		}
+		updated := time.Now()
+		if err := w.draw(dt); err != nil {
+			return SceneResultError, setSceneErrorFrame(err, w.frame)
+		}
+		w.stats.record(start, measured, updated.Sub(start), time.Since(updated))
+		w.present()
+		w.fps.WaitForNextFrame()
+	}
+	return SceneResultWindowClosed, nil
+}
 
-		if w.Color != nil {
-			w.canvas.Clear(w.Color)
+// draw draws the visible Scenes of the scene stack and
+// any Transition in progress onto the canvas. Drawing stops
+// at the first Scene that fails.
+func (w *World) draw(dt float64) error {
+	target := w.canvas
+	if w.transition.active() {
+		target = w.transition.to
+	}
+	if w.Color != nil {
+		target.Clear(w.Color)
+	}
+	alpha := w.step.alpha()
+	for _, entry := range w.stack.visible() {
+		target.SetMatrix(pixel.IM)
+		interpolateScene(entry.scene, alpha)
+		if err := drawScene(entry, target); err != nil {
+			return err
 		}
-		w.canvas.SetMatrix(pixel.IM)
-		scene.Draw(w.canvas)
+	}
+	if w.transition.active() {
+		w.drawTransition(dt)
+	}
+	return nil
+}
 
-		w.drawToWindow()
-		w.fps.WaitForNextFrame()
+// present draws the stats overlay, if it is shown,
+// and displays the canvas on the window.
+func (w *World) present() {
+	if w.showStats {
+		if w.statsOverlay == nil {
+			w.statsOverlay = newStatsOverlay()
+		}
+		w.statsOverlay.draw(w.canvas, w.stats.stats(), w.stats.recent(statsGraphFrames))
+	}
+	w.drawToWindow()
+}
+
+// update updates the scene stack for a single frame, using fixed
+// time steps if they are enabled. SceneResultPop is returned
+// once the scene stack is empty.
+//
+// Fixed steps are counted in unscaled time, so a paused World keeps
+// updating its Scenes with a time delta of 0.
+func (w *World) update(dt float64) (SceneResult, error) {
+	if !w.step.enabled() {
+		return w.updateStack(NewTimeStep(dt, w.timeScale), w.input)
+	}
+	w.latched.latch()
+	steps := w.step.advance(dt)
+	for i := 0; i < steps; i++ {
+		result, err := w.updateStack(NewTimeStep(w.step.step, w.timeScale), w.latched)
+		w.latched.consume()
+		if result != SceneResultNone {
+			return result, err
+		}
+	}
+	return SceneResultNone, nil
+}
+
+// updateStack updates the scene stack once.
+func (w *World) updateStack(step TimeStep, input Input) (SceneResult, error) {
+	result, err := w.stack.update(step, input)
+	if result == SceneResultNone && w.stack.len() == 0 {
+		return SceneResultPop, nil
+	}
+	return result, err
+}
+
+// beginTransition starts a Transition from the frame currently
+// on the canvas. A nil Transition stops any Transition in progress.
+func (w *World) beginTransition(transition Transition) {
+	w.transition.transition = transition
+	w.transition.elapsed = 0
+	if transition == nil {
+		return
+	}
+	if w.transition.from == nil {
+		w.transition.from = w.window.NewCanvas(w.canvas.Bounds())
+		w.transition.to = w.window.NewCanvas(w.canvas.Bounds())
+	}
+
+	w.transition.from.Clear(transparent)
+	w.transition.from.SetMatrix(pixel.IM)
+	w.canvas.Draw(w.transition.from, pixel.IM.Moved(w.canvas.Bounds().Center()))
+}
+
+// drawTransition draws the Transition in progress onto the canvas
+// and ends the Transition once it is complete.
+func (w *World) drawTransition(dt float64) {
+	t := &w.transition
+	t.elapsed += dt
+	progress := transitionProgress(t.transition, t.elapsed)
+
+	if w.Color != nil {
+		w.canvas.Clear(w.Color)
+	} else {
+		w.canvas.Clear(transparent)
+	}
+	w.canvas.SetMatrix(pixel.IM)
+	t.transition.Draw(w.canvas, t.from, t.to, progress)
+
+	if progress >= 1 {
+		t.transition = nil
 	}
-	return SceneResultWindowClosed
 }
 
 // drawToWindow renders the canvas onto the window.
 func (w *World) drawToWindow() {
-	w.canvas.Draw(w.window, w.fit)
-	w.window.Update()
+	w.fitToWindow()
+	w.window.Display(w.canvas, w.fit)
+}
+
+// fitToWindow recomputes the matrix that scales the canvas onto
+// the window if the window changed size since it was last computed.
+func (w *World) fitToWindow() {
+	bounds := w.window.Bounds()
+	if bounds == w.fitBounds {
+		return
+	}
+	w.fitBounds = bounds
+	w.fit = FitMatrix(w.fitMode, w.canvas.Bounds(), bounds)
+	w.input.setFit(w.fit, w.canvas.Bounds())
+
+	logrus.WithFields(logrus.Fields{
+		"window":  bounds,
+		"fitMode": w.fitMode,
+	}).Debug("fit canvas to window")
 }
@@ -0,0 +1,164 @@
+package wotest
+
+import (
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+	"github.com/pkg/errors"
+	"golang.org/x/image/colornames"
+)
+
+// harnessSceneName is the name a Harness gives its Scene in SceneErrors.
+const harnessSceneName = "scene"
+
+// Harness runs a single Scene without a window, the way a World would:
+// every frame the Scene is updated with the Input and then drawn onto an
+// ImageCanvas. The SceneResult of every Update is recorded. Frames of
+// the Input are numbered like the frames of the Harness, so input
+// scripted with PressAt(3, ...) is seen by the third Update.
+//
+// A Harness stops once the Scene returns a result other than
+// wo.SceneResultNone, which would end the Scene in a World.
+type Harness struct {
+	scene  wo.Scene
+	input  *Input
+	canvas *wo.ImageCanvas
+
+	timeScale float64
+	draw      bool
+
+	entered bool
+	ended   bool
+	results []wo.SceneResult
+	err     error
+}
+
+// NewHarness creates a Scene with a SceneFactory, giving it an
+// ImageCanvas of the given size, and a Harness to run it.
+func NewHarness(factory wo.SceneFactory, width, height int) (*Harness, error) {
+	canvas := wo.NewImageCanvas(pixel.R(0, 0, float64(width), float64(height)))
+	scene, err := factory(canvas)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create scene")
+	}
+	return &Harness{
+		scene:     scene,
+		input:     NewInput(),
+		canvas:    canvas,
+		timeScale: 1,
+		draw:      true,
+	}, nil
+}
+
+// Scene returns the Scene being run.
+func (h *Harness) Scene() wo.Scene {
+	return h.scene
+}
+
+// Input returns the Input the Scene is updated with.
+func (h *Harness) Input() *Input {
+	return h.input
+}
+
+// Canvas returns the ImageCanvas the Scene is drawn onto.
+func (h *Harness) Canvas() *wo.ImageCanvas {
+	return h.canvas
+}
+
+// SetTimeScale scales the time deltas the Scene is updated with, as World.SetTimeScale does.
+func (h *Harness) SetTimeScale(scale float64) {
+	h.timeScale = scale
+}
+
+// SetDraw sets whether the Scene is drawn after every Update. Scenes
+// are drawn by default; not drawing them makes long tests faster.
+func (h *Harness) SetDraw(draw bool) {
+	h.draw = draw
+}
+
+// Step runs the Scene for a number of frames that each last dt seconds
+// and returns the SceneResults of their Updates. It stops early once
+// the Scene has ended.
+func (h *Harness) Step(frames int, dt float64) []wo.SceneResult {
+	var results []wo.SceneResult
+	for i := 0; i < frames && !h.ended; i++ {
+		results = append(results, h.step(dt))
+	}
+	return results
+}
+
+// RunUntilResult runs the Scene for at most maxFrames frames that each
+// last dt seconds, until it returns a result other than wo.SceneResultNone.
+// It returns that result, or wo.SceneResultNone if the Scene did not end.
+func (h *Harness) RunUntilResult(maxFrames int, dt float64) wo.SceneResult {
+	h.Step(maxFrames, dt)
+	return h.Result()
+}
+
+// step runs a single frame.
+func (h *Harness) step(dt float64) wo.SceneResult {
+	if !h.entered {
+		h.entered = true
+		wo.EnterScene(h.scene)
+	}
+
+	result, err := wo.UpdateScene(harnessSceneName, h.scene, wo.NewTimeStep(dt, h.timeScale), h.input)
+	if err == nil && h.draw {
+		h.canvas.Clear(colornames.Black)
+		h.canvas.SetMatrix(pixel.IM)
+		err = wo.DrawScene(harnessSceneName, h.scene, h.canvas)
+		if err != nil {
+			result = wo.SceneResultError
+		}
+	}
+	h.input.NextFrame()
+	h.results = append(h.results, result)
+	if result != wo.SceneResultNone {
+		h.err = err
+		h.end()
+	}
+	return result
+}
+
+// Frame returns the number of frames the Scene has run.
+func (h *Harness) Frame() int {
+	return len(h.results)
+}
+
+// Results returns the SceneResults of every Update so far.
+func (h *Harness) Results() []wo.SceneResult {
+	return h.results
+}
+
+// Result returns the SceneResult that ended the Scene,
+// or wo.SceneResultNone if it has not ended.
+func (h *Harness) Result() wo.SceneResult {
+	if !h.ended || len(h.results) == 0 {
+		return wo.SceneResultNone
+	}
+	return h.results[len(h.results)-1]
+}
+
+// Err returns the error that ended the Scene, a *wo.SceneError, if it failed.
+func (h *Harness) Err() error {
+	return h.err
+}
+
+// Ended returns whether the Scene has ended.
+func (h *Harness) Ended() bool {
+	return h.ended
+}
+
+// Close ends the Scene if it has not ended yet.
+func (h *Harness) Close() {
+	if !h.ended {
+		h.end()
+	}
+}
+
+// end exits the Scene if it was entered.
+func (h *Harness) end() {
+	h.ended = true
+	if h.entered {
+		wo.ExitScene(h.scene)
+	}
+}
@@ -0,0 +1,107 @@
+package wotest
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
+)
+
+const sceneResultGoToGame wo.SceneResult = 1
+
+// titleScene goes to the game when Space is pressed.
+type titleScene struct {
+	entered, exited bool
+	time            float64
+	draws           int
+	fail            bool
+}
+
+func newTitleSceneFactory(scene *titleScene) wo.SceneFactory {
+	return func(canvas wo.Canvas) (wo.Scene, error) {
+		return scene, nil
+	}
+}
+
+func (s *titleScene) Enter() {
+	s.entered = true
+}
+
+func (s *titleScene) Exit() {
+	s.exited = true
+}
+
+func (s *titleScene) Update(dt float64, input wo.Input) wo.SceneResult {
+	s.time += dt
+	if s.fail {
+		return wo.SceneResultError
+	}
+	if input.JustPressed(pixelgl.KeySpace) {
+		return sceneResultGoToGame
+	}
+	return wo.SceneResultNone
+}
+
+func (s *titleScene) Draw(canvas wo.Canvas) {
+	s.draws++
+	canvas.Clear(colornames.Red)
+}
+
+func TestHarness_Step(t *testing.T) {
+	scene := &titleScene{}
+	harness, err := NewHarness(newTitleSceneFactory(scene), 4, 4)
+	assert.NoError(t, err)
+	harness.Input().PressAt(3, pixelgl.KeySpace)
+
+	results := harness.Step(5, 0.5)
+
+	assert.Equal(t, []wo.SceneResult{wo.SceneResultNone, wo.SceneResultNone, sceneResultGoToGame}, results)
+	assert.Equal(t, sceneResultGoToGame, harness.Result())
+	assert.Equal(t, 3, harness.Frame())
+	assert.True(t, harness.Ended())
+	assert.True(t, scene.entered)
+	assert.True(t, scene.exited)
+	assert.Equal(t, 1.5, scene.time)
+	assert.Equal(t, 3, scene.draws)
+	assert.Equal(t, color.RGBA{R: 0xff, A: 0xff}, harness.Canvas().Image().RGBAAt(1, 1))
+
+	assert.Nil(t, harness.Step(1, 0.5))
+}
+
+func TestHarness_RunUntilResult(t *testing.T) {
+	scene := &titleScene{}
+	harness, _ := NewHarness(newTitleSceneFactory(scene), 1, 1)
+	harness.SetDraw(false)
+	harness.SetTimeScale(0.5)
+
+	assert.Equal(t, wo.SceneResultNone, harness.RunUntilResult(10, 1))
+	assert.False(t, harness.Ended())
+	assert.Equal(t, 5.0, scene.time)
+	assert.Equal(t, 0, scene.draws)
+
+	harness.Input().Press(pixelgl.KeySpace)
+	assert.Equal(t, sceneResultGoToGame, harness.RunUntilResult(10, 1))
+
+	harness.Close()
+	assert.True(t, scene.exited)
+}
+
+func TestHarness_error(t *testing.T) {
+	scene := &titleScene{fail: true}
+	harness, _ := NewHarness(newTitleSceneFactory(scene), 1, 1)
+
+	assert.Equal(t, wo.SceneResultError, harness.RunUntilResult(10, 1))
+	assert.IsType(t, &wo.SceneError{}, harness.Err())
+}
+
+func TestNewHarness_error(t *testing.T) {
+	_, err := NewHarness(func(canvas wo.Canvas) (wo.Scene, error) {
+		return nil, errors.New("boom")
+	}, 1, 1)
+
+	assert.EqualError(t, err, "unable to create scene: boom")
+}
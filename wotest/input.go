@@ -0,0 +1,211 @@
+package wotest
+
+import (
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+)
+
+// Input is a wo.Input whose user input is set by tests, either in the
+// current frame or scripted to happen on a given frame. Frames are
+// numbered from 1 and end with NextFrame, which a Harness calls after
+// every Update, so the current frame is the one the next Update sees.
+//
+// Input also reads gamepads, which are connected and moved through
+// its FakeGamepadBackend.
+type Input struct {
+	wo.GamepadInput
+
+	frame    int
+	script   map[int][]func(input *Input)
+	gamepads *wo.Gamepads
+	backend  *wo.FakeGamepadBackend
+
+	pressed      map[pixelgl.Button]bool
+	justPressed  map[pixelgl.Button]bool
+	justReleased map[pixelgl.Button]bool
+	repeated     map[pixelgl.Button]bool
+	mouse        pixel.Vec
+	scroll       pixel.Vec
+	typed        string
+}
+
+var (
+	_ wo.Input        = &Input{}
+	_ wo.GamepadInput = &Input{}
+)
+
+// NewInput creates an Input without any user input.
+func NewInput() *Input {
+	backend := wo.NewFakeGamepadBackend()
+	gamepads := wo.NewGamepads(backend)
+	return &Input{
+		GamepadInput: gamepads,
+		frame:        1,
+		script:       make(map[int][]func(input *Input)),
+		gamepads:     gamepads,
+		backend:      backend,
+		pressed:      make(map[pixelgl.Button]bool),
+		justPressed:  make(map[pixelgl.Button]bool),
+		justReleased: make(map[pixelgl.Button]bool),
+		repeated:     make(map[pixelgl.Button]bool),
+	}
+}
+
+// Frame returns the number of the current frame.
+func (i *Input) Frame() int {
+	return i.frame
+}
+
+// NextFrame ends the current frame: its single-frame events, such as
+// JustPressed, are cleared, the input scripted for the next frame
+// happens and the gamepads are read.
+func (i *Input) NextFrame() {
+	i.frame++
+	i.justPressed = make(map[pixelgl.Button]bool)
+	i.justReleased = make(map[pixelgl.Button]bool)
+	i.repeated = make(map[pixelgl.Button]bool)
+	i.scroll = pixel.ZV
+	i.typed = ""
+
+	script := i.script[i.frame]
+	delete(i.script, i.frame)
+	for _, f := range script {
+		f(i)
+	}
+	i.gamepads.Update()
+}
+
+// GamepadBackend returns the FakeGamepadBackend the gamepads are read
+// from. Changes to it are seen once the current frame ends.
+func (i *Input) GamepadBackend() *wo.FakeGamepadBackend {
+	return i.backend
+}
+
+// SetGamepadDeadzone sets the deadzone of the analog axes of the gamepads.
+func (i *Input) SetGamepadDeadzone(deadzone float64) {
+	i.gamepads.SetDeadzone(deadzone)
+}
+
+// Press presses Buttons down in the current frame.
+func (i *Input) Press(buttons ...pixelgl.Button) {
+	for _, b := range buttons {
+		if !i.pressed[b] {
+			i.justPressed[b] = true
+		}
+		i.pressed[b] = true
+	}
+}
+
+// Release releases Buttons in the current frame.
+func (i *Input) Release(buttons ...pixelgl.Button) {
+	for _, b := range buttons {
+		if i.pressed[b] {
+			i.justReleased[b] = true
+		}
+		i.pressed[b] = false
+	}
+}
+
+// Repeat triggers a repeat event on Buttons in the current frame.
+func (i *Input) Repeat(buttons ...pixelgl.Button) {
+	for _, b := range buttons {
+		i.repeated[b] = true
+	}
+}
+
+// Type types text in the current frame.
+func (i *Input) Type(text string) {
+	i.typed += text
+}
+
+// MoveMouse moves the mouse to a position.
+func (i *Input) MoveMouse(pos pixel.Vec) {
+	i.mouse = pos
+}
+
+// Scroll scrolls the mouse in the current frame.
+func (i *Input) Scroll(scroll pixel.Vec) {
+	i.scroll = i.scroll.Add(scroll)
+}
+
+// Do calls f at the start of a frame, before the gamepads are read.
+// Input scripted for the current frame happens right away and input
+// scripted for earlier frames never happens.
+func (i *Input) Do(frame int, f func(input *Input)) {
+	switch {
+	case frame == i.frame:
+		f(i)
+	case frame > i.frame:
+		i.script[frame] = append(i.script[frame], f)
+	}
+}
+
+// PressAt presses Buttons down on a frame.
+func (i *Input) PressAt(frame int, buttons ...pixelgl.Button) {
+	i.Do(frame, func(input *Input) { input.Press(buttons...) })
+}
+
+// ReleaseAt releases Buttons on a frame.
+func (i *Input) ReleaseAt(frame int, buttons ...pixelgl.Button) {
+	i.Do(frame, func(input *Input) { input.Release(buttons...) })
+}
+
+// TapAt presses Buttons down on a frame and releases them on the next.
+func (i *Input) TapAt(frame int, buttons ...pixelgl.Button) {
+	i.PressAt(frame, buttons...)
+	i.ReleaseAt(frame+1, buttons...)
+}
+
+// TypeAt types text on a frame.
+func (i *Input) TypeAt(frame int, text string) {
+	i.Do(frame, func(input *Input) { input.Type(text) })
+}
+
+// MoveMouseAt moves the mouse to a position on a frame.
+func (i *Input) MoveMouseAt(frame int, pos pixel.Vec) {
+	i.Do(frame, func(input *Input) { input.MoveMouse(pos) })
+}
+
+// ScrollAt scrolls the mouse on a frame.
+func (i *Input) ScrollAt(frame int, scroll pixel.Vec) {
+	i.Do(frame, func(input *Input) { input.Scroll(scroll) })
+}
+
+func (i *Input) Pressed(button ...pixelgl.Button) bool {
+	return anyButton(i.pressed, button)
+}
+
+func (i *Input) JustPressed(button ...pixelgl.Button) bool {
+	return anyButton(i.justPressed, button)
+}
+
+func (i *Input) JustReleased(button ...pixelgl.Button) bool {
+	return anyButton(i.justReleased, button)
+}
+
+func (i *Input) Repeated(button ...pixelgl.Button) bool {
+	return anyButton(i.repeated, button)
+}
+
+func (i *Input) MousePosition() pixel.Vec {
+	return i.mouse
+}
+
+func (i *Input) MouseScroll() pixel.Vec {
+	return i.scroll
+}
+
+func (i *Input) Typed() string {
+	return i.typed
+}
+
+// anyButton returns whether any of the Buttons is set in a state.
+func anyButton(state map[pixelgl.Button]bool, buttons []pixelgl.Button) bool {
+	for _, b := range buttons {
+		if state[b] {
+			return true
+		}
+	}
+	return false
+}
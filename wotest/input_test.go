@@ -0,0 +1,76 @@
+package wotest
+
+import (
+	"testing"
+
+	"github.com/explodes/go-wo"
+	"github.com/faiface/pixel"
+	"github.com/faiface/pixel/pixelgl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInput_script(t *testing.T) {
+	input := NewInput()
+	input.TapAt(2, pixelgl.KeySpace)
+	input.TypeAt(2, "hi")
+	input.MoveMouseAt(3, pixel.V(4, 5))
+	input.ScrollAt(3, pixel.V(0, 1))
+
+	assert.Equal(t, 1, input.Frame())
+	assert.False(t, input.Pressed(pixelgl.KeySpace))
+
+	input.NextFrame()
+	assert.Equal(t, 2, input.Frame())
+	assert.True(t, input.Pressed(pixelgl.KeySpace))
+	assert.True(t, input.JustPressed(pixelgl.KeySpace))
+	assert.Equal(t, "hi", input.Typed())
+
+	input.NextFrame()
+	assert.False(t, input.Pressed(pixelgl.KeySpace))
+	assert.False(t, input.JustPressed(pixelgl.KeySpace))
+	assert.True(t, input.JustReleased(pixelgl.KeySpace))
+	assert.Equal(t, "", input.Typed())
+	assert.Equal(t, pixel.V(4, 5), input.MousePosition())
+	assert.Equal(t, pixel.V(0, 1), input.MouseScroll())
+
+	input.NextFrame()
+	assert.False(t, input.JustReleased(pixelgl.KeySpace))
+	assert.Equal(t, pixel.V(4, 5), input.MousePosition())
+	assert.Equal(t, pixel.ZV, input.MouseScroll())
+}
+
+func TestInput_scriptCurrentFrame(t *testing.T) {
+	input := NewInput()
+	input.NextFrame()
+
+	input.PressAt(1, pixelgl.KeyA)
+	input.PressAt(2, pixelgl.KeyB)
+
+	assert.False(t, input.Pressed(pixelgl.KeyA))
+	assert.True(t, input.JustPressed(pixelgl.KeyB))
+}
+
+func TestInput_holdIsNotPressedAgain(t *testing.T) {
+	input := NewInput()
+
+	input.Press(pixelgl.KeyA)
+	input.NextFrame()
+	input.Press(pixelgl.KeyA)
+
+	assert.True(t, input.Pressed(pixelgl.KeyA))
+	assert.False(t, input.JustPressed(pixelgl.KeyA))
+}
+
+func TestInput_gamepads(t *testing.T) {
+	input := NewInput()
+	input.GamepadBackend().Connect(wo.Joystick1, "pad", 2, 0)
+	input.Do(2, func(input *Input) {
+		input.GamepadBackend().SetButton(wo.Joystick1, 1, true)
+	})
+
+	assert.False(t, wo.InputGamepads(input).JoystickPresent(wo.Joystick1))
+
+	input.NextFrame()
+	assert.True(t, wo.InputGamepads(input).JoystickJustConnected(wo.Joystick1))
+	assert.True(t, input.JoystickJustPressed(wo.Joystick1, 1))
+}